@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// AirportQuery filters the airports, the fields projection leaves the other fields empty
+type AirportQuery struct {
+	IataCodes   []string
+	CountryCode string
+	Fields      []string
+	ListOptions
+}
+
+// GetAirport returns an airport by IATA code
+func (c *Client) GetAirport(ctx context.Context, iataCode string) (*model.AirportV2, error) {
+	var airport model.AirportV2
+
+	if _, err := c.get(ctx, "/v2/airports/"+url.PathEscape(iataCode), nil, &airport); err != nil {
+		return nil, err
+	}
+
+	return &airport, nil
+}
+
+// GetAirports returns a page of the airports sorted by IATA code
+func (c *Client) GetAirports(ctx context.Context, q AirportQuery) ([]model.AirportV2, *Page, error) {
+	qp := url.Values{}
+
+	if len(q.IataCodes) > 0 {
+		qp.Set("iata_codes", joinIDs(q.IataCodes))
+	}
+
+	if q.CountryCode != "" {
+		qp.Set("country_code", q.CountryCode)
+	}
+
+	if len(q.Fields) > 0 {
+		qp.Set("fields", strings.Join(q.Fields, ","))
+	}
+
+	q.ListOptions.values(qp)
+
+	airports := make([]model.AirportV2, 0)
+
+	h, err := c.get(ctx, "/v2/airports", qp, &airports)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return airports, pageOf(h), nil
+}
+
+// ExpandAirportCode returns the airports of a metro area code, of the cities with a city code, or the airport of an airport code
+func (c *Client) ExpandAirportCode(ctx context.Context, code string) ([]model.AirportV2, error) {
+	airports := make([]model.AirportV2, 0)
+
+	if _, err := c.get(ctx, "/v2/airports/expand/"+url.PathEscape(code), nil, &airports); err != nil {
+		return nil, err
+	}
+
+	return airports, nil
+}
+
+// SaveAirport creates an airport
+func (c *Client) SaveAirport(ctx context.Context, airport *model.AirportV2) (*model.AirportV2, error) {
+	var saved model.AirportV2
+
+	if err := c.send(ctx, http.MethodPost, "/v2/airports", airport, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateAirport replaces an airport
+func (c *Client) UpdateAirport(ctx context.Context, airport *model.AirportV2) (*model.AirportV2, error) {
+	var updated model.AirportV2
+
+	if err := c.send(ctx, http.MethodPut, "/v2/airports", airport, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// MetroAreaQuery filters the metro areas
+type MetroAreaQuery struct {
+	CountryCode string
+	CityID      string
+	Airport     string
+}
+
+// GetMetroArea returns a metro area by code
+func (c *Client) GetMetroArea(ctx context.Context, code string) (*model.MetroArea, error) {
+	var metro model.MetroArea
+
+	if _, err := c.get(ctx, "/v2/metro-areas/"+url.PathEscape(code), nil, &metro); err != nil {
+		return nil, err
+	}
+
+	return &metro, nil
+}
+
+// GetMetroAreas returns the metro areas that match the query
+func (c *Client) GetMetroAreas(ctx context.Context, q MetroAreaQuery) ([]model.MetroArea, error) {
+	qp := url.Values{}
+
+	if q.CountryCode != "" {
+		qp.Set("country_code", q.CountryCode)
+	}
+
+	if q.CityID != "" {
+		qp.Set("city_id", q.CityID)
+	}
+
+	if q.Airport != "" {
+		qp.Set("airport", q.Airport)
+	}
+
+	metros := make([]model.MetroArea, 0)
+
+	if _, err := c.get(ctx, "/v2/metro-areas", qp, &metros); err != nil {
+		return nil, err
+	}
+
+	return metros, nil
+}
+
+// SaveMetroArea creates a metro area
+func (c *Client) SaveMetroArea(ctx context.Context, metro *model.MetroArea) (*model.MetroArea, error) {
+	var saved model.MetroArea
+
+	if err := c.send(ctx, http.MethodPost, "/v2/metro-areas", metro, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateMetroArea replaces a metro area
+func (c *Client) UpdateMetroArea(ctx context.Context, metro *model.MetroArea) (*model.MetroArea, error) {
+	var updated model.MetroArea
+
+	if err := c.send(ctx, http.MethodPut, "/v2/metro-areas", metro, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// CustomAreaQuery filters the custom areas, they are paged by cursor only
+type CustomAreaQuery struct {
+	Owner      string
+	Tag        string
+	AncestorID string
+	Limit      int
+	Cursor     string
+}
+
+// GetCustomArea returns a custom area by id
+func (c *Client) GetCustomArea(ctx context.Context, geoID string) (*model.CustomArea, error) {
+	var area model.CustomArea
+
+	if _, err := c.get(ctx, "/v2/custom-areas/"+url.PathEscape(geoID), nil, &area); err != nil {
+		return nil, err
+	}
+
+	return &area, nil
+}
+
+// GetCustomAreas returns a page of the custom areas
+func (c *Client) GetCustomAreas(ctx context.Context, q CustomAreaQuery) ([]model.CustomArea, *Page, error) {
+	qp := url.Values{}
+
+	if q.Owner != "" {
+		qp.Set("owner", q.Owner)
+	}
+
+	if q.Tag != "" {
+		qp.Set("tag", q.Tag)
+	}
+
+	if q.AncestorID != "" {
+		qp.Set("ancestor_id", q.AncestorID)
+	}
+
+	ListOptions{Limit: q.Limit, Cursor: q.Cursor}.values(qp)
+
+	areas := make([]model.CustomArea, 0)
+
+	h, err := c.get(ctx, "/v2/custom-areas", qp, &areas)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return areas, pageOf(h), nil
+}
+
+// SaveCustomArea creates a custom area, the api assigns its id
+func (c *Client) SaveCustomArea(ctx context.Context, area *model.CustomArea) (*model.CustomArea, error) {
+	var saved model.CustomArea
+
+	if err := c.send(ctx, http.MethodPost, "/v2/custom-areas", area, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateCustomArea replaces the custom area with the id of the area
+func (c *Client) UpdateCustomArea(ctx context.Context, area *model.CustomArea) (*model.CustomArea, error) {
+	var updated model.CustomArea
+
+	if err := c.send(ctx, http.MethodPut, "/v2/custom-areas/"+url.PathEscape(area.GeoID), area, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteCustomArea removes a custom area
+func (c *Client) DeleteCustomArea(ctx context.Context, geoID string) error {
+	return c.send(ctx, http.MethodDelete, "/v2/custom-areas/"+url.PathEscape(geoID), nil, nil)
+}
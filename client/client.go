@@ -0,0 +1,266 @@
+// Package client is a typed Go client for the V2 routes of the api, it returns the model types
+// and maps the error responses to typed errors:
+//
+//	c := client.New("https://internal.basset.ws/geo", client.WithRetries(3, 200*time.Millisecond))
+//
+//	city, err := c.GetRegion(ctx, model.RegionTypeCity, "2")
+//	if errors.Is(err, client.ErrNotFound) { ... }
+//
+// Deadlines are taken from the context. Idempotent requests are retried on network errors,
+// 429 and 5xx responses, with an exponential backoff.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBackoff caps the wait between retries
+const maxBackoff = 10 * time.Second
+
+// Client calls the V2 routes of the api
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+	headers    http.Header
+}
+
+// Option configures a Client
+type Option func(c *Client)
+
+// WithHTTPClient replaces the http.Client, e.g. to set a timeout for all the requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTransport replaces the transport of the http.Client, e.g. to add tracing or auth
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
+// WithRetries retries the idempotent requests, waiting backoff before the first retry and doubling it after each one
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries, c.backoff = retries, backoff
+	}
+}
+
+// WithHeader adds a header to all the requests
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// New creates a client of the api at the base url, the app path included
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{},
+		backoff:    100 * time.Millisecond,
+		headers:    http.Header{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ListOptions are the paging options of the list routes
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Total  bool
+}
+
+func (o ListOptions) values(qp url.Values) {
+	if o.Limit > 0 {
+		qp.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.Cursor != "" {
+		qp.Set("cursor", o.Cursor)
+	}
+
+	if o.Total {
+		qp.Set("total", "true")
+	}
+}
+
+// Page tells how to get the next page of a list
+type Page struct {
+	// NextCursor is empty on the last page
+	NextCursor string
+	// Total is the count of all the results, -1 when it was not asked
+	Total int
+}
+
+func pageOf(h http.Header) *Page {
+	p := &Page{Total: -1}
+
+	if total, err := strconv.Atoi(h.Get("X-Total-Count")); err == nil {
+		p.Total = total
+	}
+
+	link := h.Get("Link")
+
+	if start, end := strings.Index(link, "<"), strings.Index(link, ">"); start >= 0 && end > start && strings.Contains(link, `rel="next"`) {
+		if next, err := url.Parse(link[start+1 : end]); err == nil {
+			p.NextCursor = next.Query().Get("cursor")
+		}
+	}
+
+	return p
+}
+
+// request describes a call to the api
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) (http.Header, error) {
+	return c.do(ctx, request{method: http.MethodGet, path: path, query: query}, out)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body, out interface{}) error {
+	_, err := c.do(ctx, request{method: method, path: path, body: body}, out)
+
+	return err
+}
+
+// do runs the request with its retries and decodes the body of a successful response into out
+func (c *Client) do(ctx context.Context, req request, out interface{}) (http.Header, error) {
+	res, err := c.roundTrip(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if out != nil && res.StatusCode != http.StatusNoContent {
+		if err = json.NewDecoder(res.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("failed to decode %s %s response. %w", req.method, req.path, err)
+		}
+	}
+
+	return res.Header, nil
+}
+
+// roundTrip returns the successful response, its body must be closed
+func (c *Client) roundTrip(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+
+	if r, ok := req.body.(io.Reader); ok {
+		// Streamed bodies can not be sent again
+		res, _, err := c.attempt(ctx, req, r)
+
+		return res, err
+	}
+
+	if req.body != nil {
+		var err error
+
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("failed to encode %s %s body. %w", req.method, req.path, err)
+		}
+	}
+
+	retries := 0
+
+	if req.method != http.MethodPost {
+		retries = c.retries
+	}
+
+	wait := c.backoff
+
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		res, retry, err := c.attempt(ctx, req, reader)
+
+		if err == nil || !retry || attempt >= retries || ctx.Err() != nil {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if wait *= 2; wait > maxBackoff {
+			wait = maxBackoff
+		}
+	}
+}
+
+// attempt sends the request once, an error status is returned as an *Error.
+// Network errors, 429 and 5xx responses can be retried.
+func (c *Client) attempt(ctx context.Context, req request, body io.Reader) (*http.Response, bool, error) {
+	u := c.baseURL + req.path
+
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create %s %s request. %w", req.method, req.path, err)
+	}
+
+	for k, v := range c.headers {
+		httpReq.Header[k] = v
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to call %s %s. %w", req.method, req.path, err)
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, false, nil
+	}
+
+	defer res.Body.Close()
+
+	blob, _ := ioutil.ReadAll(io.LimitReader(res.Body, 64*1024))
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+
+	return nil, retry, newError(res.StatusCode, blob)
+}
+
+func joinIDs(ids []string) string {
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/export"
+	"github.com/basset-la/api-geo/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL+"/geo/", opts...)
+}
+
+func TestGetRegion(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/geo/v2/cities/2", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		_ = json.NewEncoder(w).Encode(model.Region{
+			BaseRegion:  model.BaseRegion{GeoID: "2", Type: model.RegionTypeCity},
+			Name:        map[model.Language]string{"es": "Buenos Aires"},
+			CountryCode: "AR",
+		})
+	}, WithHeader("X-Api-Key", "secret"))
+
+	region, err := c.GetRegion(context.Background(), model.RegionTypeCity, "2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2", region.GeoID)
+	assert.Equal(t, "Buenos Aires", region.Name["es"])
+	assert.Equal(t, "AR", region.CountryCode)
+}
+
+func TestGetRegionWithoutRoute(t *testing.T) {
+	c := New("http://localhost")
+
+	_, err := c.GetRegion(context.Background(), model.RegionType("unknown"), "2")
+
+	assert.Error(t, err)
+}
+
+func TestErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/geo/v2/airports/XXX":
+			w.WriteHeader(http.StatusNotFound)
+		case "/geo/v2/airports":
+			if r.Method == http.MethodPost {
+				err := pkgErrors.Validation([]*pkgErrors.Error{pkgErrors.New(pkgErrors.CodeInvalidIATACode, `"eze" must be three uppercase letters`).WithField("iata_code")})
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(err)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"INVALID_PARAMETER","message":"[limit] must be a positive number","field":"limit"}`))
+		default:
+			http.Error(w, "invalid geometry", http.StatusUnprocessableEntity)
+		}
+	})
+
+	_, err := c.GetAirport(context.Background(), "XXX")
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, pkgErrors.ErrEntityNotFound))
+	assert.False(t, errors.Is(err, ErrServer))
+
+	_, _, err = c.GetAirports(context.Background(), AirportQuery{ListOptions: ListOptions{Limit: -1}})
+
+	var apiErr *Error
+
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, pkgErrors.CodeInvalidParameter, apiErr.Code)
+	assert.Equal(t, "[limit] must be a positive number", apiErr.Message)
+	assert.Equal(t, "limit", apiErr.Field)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	_, err = c.SaveAirport(context.Background(), &model.AirportV2{IataCode: "eze"})
+
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, pkgErrors.CodeInvalidPayload, apiErr.Code)
+	assert.Equal(t, []FieldError{{Code: pkgErrors.CodeInvalidIATACode, Message: `[iata_code] "eze" must be three uppercase letters`, Field: "iata_code"}}, apiErr.Fields)
+
+	_, err = c.GeometryOps(context.Background(), &model.GeometryOpsRequest{})
+
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "invalid geometry", apiErr.Message)
+	assert.True(t, errors.Is(err, ErrUnprocessable))
+}
+
+func TestRetries(t *testing.T) {
+	var calls int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(model.AirportV2{})
+	}, WithRetries(3, time.Millisecond))
+
+	_, err := c.GetAirport(context.Background(), "EZE")
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+
+	_, err = c.SaveAirport(context.Background(), &model.AirportV2{})
+
+	assert.True(t, errors.Is(err, ErrServer))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "POST is not retried")
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	var calls int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetries(2, time.Millisecond))
+
+	_, err := c.GetAirport(context.Background(), "EZE")
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestContextDeadline(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}, WithRetries(5, 10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetAirport(ctx, "EZE")
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+}
+
+func TestPaging(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/geo/v2/countries", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "abc", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "true", r.URL.Query().Get("total"))
+		assert.Equal(t, "AR", r.URL.Query().Get("country_code"))
+
+		w.Header().Set("Link", `<http://localhost/geo/v2/countries?cursor=def&limit=10>; rel="next"`)
+		w.Header().Set("X-Total-Count", "42")
+		_, _ = w.Write([]byte(`[{"id":"178","type":"country"}]`))
+	})
+
+	regions, page, err := c.GetRegions(context.Background(), model.RegionTypeCountry, RegionQuery{
+		CountryCode: "AR",
+		ListOptions: ListOptions{Limit: 10, Cursor: "abc", Total: true},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "178", regions[0].GeoID)
+	assert.Equal(t, "def", page.NextCursor)
+	assert.Equal(t, 42, page.Total)
+}
+
+func TestLastPage(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	areas, page, err := c.GetCustomAreas(context.Background(), CustomAreaQuery{Owner: "ops"})
+
+	require.NoError(t, err)
+	assert.Empty(t, areas)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, -1, page.Total)
+}
+
+type recordingTransport struct {
+	calls int
+}
+
+func (rt *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.calls++
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	rt := &recordingTransport{}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}, WithTransport(rt))
+
+	require.NoError(t, c.DeleteCustomArea(context.Background(), "area-1"))
+	assert.Equal(t, 1, rt.calls)
+}
+
+func TestExportRegions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "country,city", r.URL.Query().Get("types"))
+		assert.Equal(t, "ndjson", r.URL.Query().Get("format"))
+
+		writer, err := export.NewWriter(export.FormatNDJSON, w, nil)
+		require.NoError(t, err)
+
+		for _, id := range []string{"178", "2"} {
+			require.NoError(t, writer.Write(&model.RegionExport{Region: model.Region{BaseRegion: model.BaseRegion{GeoID: id}}}))
+		}
+
+		require.NoError(t, writer.Close())
+	})
+
+	types := []model.RegionType{model.RegionTypeCountry, model.RegionTypeCity}
+
+	var ids []string
+
+	err := c.ExportRegions(context.Background(), types, false, func(r *model.RegionExport) error {
+		ids = append(ids, r.GeoID)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"178", "2"}, ids)
+
+	stop := errors.New("stop")
+
+	err = c.ExportRegions(context.Background(), types, false, func(r *model.RegionExport) error {
+		return stop
+	})
+
+	assert.True(t, errors.Is(err, stop))
+}
+
+func TestDiff(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "country", r.URL.Query().Get("types"))
+
+		_, _ = w.Write([]byte(`{"added":[],"removed":[],"changed":[],"unchanged":1}`))
+	})
+
+	changelog, err := c.Diff(context.Background(), strings.NewReader(`{"id":"178","type":"country"}`), model.RegionTypeCountry)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changelog.Unchanged)
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/basset-la/api-geo/diff"
+	"github.com/basset-la/api-geo/export"
+	"github.com/basset-la/api-geo/model"
+)
+
+// Diff compares an NDJSON export with the live regions of the types, of the types in the export when there are none.
+// The body is streamed, so it is not retried.
+func (c *Client) Diff(ctx context.Context, export io.Reader, types ...model.RegionType) (*diff.Changelog, error) {
+	var query url.Values
+
+	if len(types) > 0 {
+		query = url.Values{"types": {joinTypes(types)}}
+	}
+
+	var changelog diff.Changelog
+
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/v2/diff", query: query, body: export}, &changelog); err != nil {
+		return nil, err
+	}
+
+	return &changelog, nil
+}
+
+// ExportRegions streams the regions of the types to fn, with their polygons when asked.
+// It stops at the first error of fn.
+func (c *Client) ExportRegions(ctx context.Context, types []model.RegionType, polygons bool, fn func(r *model.RegionExport) error) error {
+	qp := url.Values{}
+	qp.Set("types", joinTypes(types))
+	qp.Set("format", string(export.FormatNDJSON))
+	qp.Set("polygons", strconv.FormatBool(polygons))
+
+	res, err := c.roundTrip(ctx, request{method: http.MethodGet, path: "/v2/export", query: qp})
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	reader := export.NewReader(res.Body)
+
+	for {
+		region, err := reader.Read()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = fn(region); err != nil {
+			return err
+		}
+	}
+}
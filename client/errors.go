@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+)
+
+// Errors to check with errors.Is against the errors of the client
+var (
+	ErrBadRequest    = errors.New("bad request")
+	ErrNotFound      = errors.New("not found")
+	ErrUnprocessable = errors.New("unprocessable entity")
+	ErrServer        = errors.New("server error")
+)
+
+// Error is a response of the api with an error status.
+// A not found error also matches the ErrEntityNotFound of the repository.
+type Error struct {
+	StatusCode int
+	// Code is the code of the errors catalogue, e.g. REGION_NOT_FOUND
+	Code    pkgErrors.Code
+	Message string
+	// Field is the invalid parameter or body field
+	Field string
+	// Fields are the errors of each field of an INVALID_PAYLOAD error
+	Fields []FieldError
+}
+
+// FieldError is the error of a field of a payload
+type FieldError struct {
+	Code    pkgErrors.Code `json:"code"`
+	Message string         `json:"message"`
+	Field   string         `json:"field"`
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("api-geo responded %d %s. %s", e.StatusCode, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("api-geo responded %d. %s", e.StatusCode, e.Message)
+}
+
+// Is matches the error with the sentinel errors of its status
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound, pkgErrors.ErrEntityNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnprocessable:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// newError reads an error body, an error of the catalogue, a JSON object with an error field or plain text
+func newError(status int, body []byte) *Error {
+	e := &Error{StatusCode: status, Message: strings.TrimSpace(string(body))}
+
+	var payload map[string]interface{}
+
+	if err := json.Unmarshal(body, &payload); err == nil {
+		code, _ := payload["code"].(string)
+		e.Code = pkgErrors.Code(code)
+		e.Field, _ = payload["field"].(string)
+
+		var fields struct {
+			Fields []FieldError `json:"fields"`
+		}
+
+		if json.Unmarshal(body, &fields) == nil {
+			e.Fields = fields.Fields
+		}
+
+		for _, key := range []string{"message", "error", "err"} {
+			if msg, ok := payload[key].(string); ok && msg != "" {
+				e.Message = msg
+
+				break
+			}
+		}
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+	}
+
+	return e
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// PolygonCenter tells the polygon routes to write a center computed from the polygon back into its region
+type PolygonCenter string
+
+// Centers computed from a polygon
+const (
+	CenterNone       PolygonCenter = ""
+	CenterCentroid   PolygonCenter = "centroid"
+	CenterLabelPoint PolygonCenter = "label_point"
+)
+
+// GetPolygon returns the polygon of a region by id
+func (c *Client) GetPolygon(ctx context.Context, geoID string) (*model.GeoRegion, error) {
+	var region model.GeoRegion
+
+	if _, err := c.get(ctx, "/v2/polygons/"+url.PathEscape(geoID), nil, &region); err != nil {
+		return nil, err
+	}
+
+	return &region, nil
+}
+
+// GetPolygonMetrics returns the area, perimeter, centroid and label point of the polygon of a region
+func (c *Client) GetPolygonMetrics(ctx context.Context, geoID string) (*model.PolygonMetrics, error) {
+	var metrics model.PolygonMetrics
+
+	if _, err := c.get(ctx, "/v2/polygons/"+url.PathEscape(geoID)+"/metrics", nil, &metrics); err != nil {
+		return nil, err
+	}
+
+	return &metrics, nil
+}
+
+// SavePolygon creates the polygon of a region
+func (c *Client) SavePolygon(ctx context.Context, region *model.GeoRegion, center PolygonCenter) (*model.GeoRegion, error) {
+	return c.writePolygon(ctx, http.MethodPost, region, center)
+}
+
+// UpdatePolygon replaces the polygon of a region
+func (c *Client) UpdatePolygon(ctx context.Context, region *model.GeoRegion, center PolygonCenter) (*model.GeoRegion, error) {
+	return c.writePolygon(ctx, http.MethodPut, region, center)
+}
+
+func (c *Client) writePolygon(ctx context.Context, method string, region *model.GeoRegion, center PolygonCenter) (*model.GeoRegion, error) {
+	var query url.Values
+
+	if center != CenterNone {
+		query = url.Values{"center": {string(center)}}
+	}
+
+	var saved model.GeoRegion
+
+	if _, err := c.do(ctx, request{method: method, path: "/v2/polygons", query: query, body: region}, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// GeometryOps applies a geometry operation. The result is the geometry of the returned region,
+// which is also saved with its id and type when the request has persist.
+func (c *Client) GeometryOps(ctx context.Context, req *model.GeometryOpsRequest) (*model.GeoRegion, error) {
+	if req.Persist != nil {
+		var region model.GeoRegion
+
+		if err := c.send(ctx, http.MethodPost, "/v2/geometry/ops", req, &region); err != nil {
+			return nil, err
+		}
+
+		return &region, nil
+	}
+
+	var geometry model.Geometry
+
+	if err := c.send(ctx, http.MethodPost, "/v2/geometry/ops", req, &geometry); err != nil {
+		return nil, err
+	}
+
+	return &model.GeoRegion{Geometry: geometry}, nil
+}
+
+// InsertAccommodation saves an accommodation and returns the regions that contain it
+func (c *Client) InsertAccommodation(ctx context.Context, accommodation *model.GeoRegion) ([]model.GeoRegion, error) {
+	regions := make([]model.GeoRegion, 0)
+
+	if err := c.send(ctx, http.MethodPost, "/v2/accommodations", accommodation, &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+// GetAccommodationClusters returns the accommodations in the bounding box grouped for the zoom level,
+// with up to sample ids per cluster, the default sample when it is 0
+func (c *Client) GetAccommodationClusters(ctx context.Context, bbox model.BoundingBox, zoom, sample int) ([]model.AccommodationCluster, error) {
+	qp := url.Values{}
+	qp.Set("bbox", formatBoundingBox(&bbox))
+	qp.Set("zoom", strconv.Itoa(zoom))
+
+	if sample > 0 {
+		qp.Set("sample", strconv.Itoa(sample))
+	}
+
+	clusters := make([]model.AccommodationCluster, 0)
+
+	if _, err := c.get(ctx, "/v2/accommodations/clusters", qp, &clusters); err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// GetGeohash returns the cell of a geohash prefix with up to limit regions and airports inside it, the default limit when it is 0
+func (c *Client) GetGeohash(ctx context.Context, prefix string, limit int) (*model.GeohashEntities, error) {
+	qp := url.Values{}
+
+	if limit > 0 {
+		qp.Set("limit", strconv.Itoa(limit))
+	}
+
+	var entities model.GeohashEntities
+
+	if _, err := c.get(ctx, "/v2/geohash/"+url.PathEscape(prefix), qp, &entities); err != nil {
+		return nil, err
+	}
+
+	return &entities, nil
+}
+
+// GetTimezone returns the timezone of a point with its offset at the date, now when the date is zero
+func (c *Client) GetTimezone(ctx context.Context, latitude, longitude float64, date time.Time) (*model.Timezone, error) {
+	qp := url.Values{}
+	qp.Set("latitude", formatFloat(latitude))
+	qp.Set("longitude", formatFloat(longitude))
+
+	if !date.IsZero() {
+		qp.Set("date", date.Format(time.RFC3339))
+	}
+
+	var tz model.Timezone
+
+	if _, err := c.get(ctx, "/v2/timezone", qp, &tz); err != nil {
+		return nil, err
+	}
+
+	return &tz, nil
+}
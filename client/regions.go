@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// regionPaths are the collections of the region routes by type
+var regionPaths = map[model.RegionType]string{
+	model.RegionTypeCountry:           "/v2/countries",
+	model.RegionTypeCity:              "/v2/cities",
+	model.RegionTypeHighLevelRegion:   "/v2/high-level-regions",
+	model.RegionTypeContinent:         "/v2/continents",
+	model.RegionTypeMultiCityVicinity: "/v2/multi-city-vicinities",
+	model.RegionTypeTrainStation:      "/v2/train-stations",
+	model.RegionTypeMetroStation:      "/v2/metro-stations",
+	model.RegionTypeProvinceState:     "/v2/province-states",
+	model.RegionTypePOI:               "/v2/points-of-interest",
+	model.RegionTypeNeighborhood:      "/v2/neighborhoods",
+}
+
+func regionPath(regionType model.RegionType) (string, error) {
+	path, ok := regionPaths[regionType]
+
+	if !ok {
+		return "", fmt.Errorf("%s regions have no route", regionType)
+	}
+
+	return path, nil
+}
+
+func regionItemPath(regionType model.RegionType, geoID string, parts ...string) string {
+	path := "/v2/regions/" + url.PathEscape(string(regionType)) + "/" + url.PathEscape(geoID)
+
+	for _, p := range parts {
+		path += "/" + url.PathEscape(p)
+	}
+
+	return path
+}
+
+// RegionQuery filters the regions of a type, the fields projection leaves the other fields empty
+type RegionQuery struct {
+	IDs             []string
+	Descendants     []string
+	Name            string
+	CountryCode     string
+	AncestorID      string
+	AncestorType    model.RegionType
+	BoundingBox     *model.BoundingBox
+	WithinPolygonOf string
+	Fields          []string
+	ListOptions
+}
+
+func (q RegionQuery) values() url.Values {
+	qp := url.Values{}
+
+	if len(q.IDs) > 0 {
+		qp.Set("ids", joinIDs(q.IDs))
+	}
+
+	if len(q.Descendants) > 0 {
+		qp.Set("descendants", joinIDs(q.Descendants))
+	}
+
+	if q.Name != "" {
+		qp.Set("name", q.Name)
+	}
+
+	if q.CountryCode != "" {
+		qp.Set("country_code", q.CountryCode)
+	}
+
+	if q.AncestorID != "" {
+		qp.Set("ancestor_id", q.AncestorID)
+	}
+
+	if q.AncestorType != "" {
+		qp.Set("ancestor_type", string(q.AncestorType))
+	}
+
+	if b := q.BoundingBox; b != nil {
+		qp.Set("bbox", formatBoundingBox(b))
+	}
+
+	if q.WithinPolygonOf != "" {
+		qp.Set("within_polygon_of", q.WithinPolygonOf)
+	}
+
+	if len(q.Fields) > 0 {
+		qp.Set("fields", strings.Join(q.Fields, ","))
+	}
+
+	q.ListOptions.values(qp)
+
+	return qp
+}
+
+func formatBoundingBox(b *model.BoundingBox) string {
+	values := []float64{b.MinLongitude, b.MinLatitude, b.MaxLongitude, b.MaxLatitude}
+	parts := make([]string, 0, len(values))
+
+	for _, v := range values {
+		parts = append(parts, formatFloat(v))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// GetRegion returns a region by type and id
+func (c *Client) GetRegion(ctx context.Context, regionType model.RegionType, geoID string) (*model.Region, error) {
+	path, err := regionPath(regionType)
+	if err != nil {
+		return nil, err
+	}
+
+	var region model.Region
+
+	if _, err = c.get(ctx, path+"/"+url.PathEscape(geoID), nil, &region); err != nil {
+		return nil, err
+	}
+
+	return &region, nil
+}
+
+// GetRegions returns a page of the regions of a type
+func (c *Client) GetRegions(ctx context.Context, regionType model.RegionType, q RegionQuery) ([]model.Region, *Page, error) {
+	path, err := regionPath(regionType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	regions := make([]model.Region, 0)
+
+	h, err := c.get(ctx, path, q.values(), &regions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return regions, pageOf(h), nil
+}
+
+// SaveRegion creates a region
+func (c *Client) SaveRegion(ctx context.Context, region *model.Region) (*model.Region, error) {
+	var saved model.Region
+
+	if err := c.send(ctx, http.MethodPost, "/v2/regions", region, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateRegion replaces a region
+func (c *Client) UpdateRegion(ctx context.Context, region *model.Region) (*model.Region, error) {
+	var updated model.Region
+
+	if err := c.send(ctx, http.MethodPut, "/v2/regions", region, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// AccommodationsQuery pages the accommodations inside the polygon of a region, by id or by distance to its center.
+// Pages sorted by distance are requested by number.
+type AccommodationsQuery struct {
+	SortByDistance bool
+	Page           int
+	ListOptions
+}
+
+// GetRegionAccommodations returns a page of the accommodations inside the polygon of a region
+func (c *Client) GetRegionAccommodations(ctx context.Context, regionType model.RegionType, geoID string, q AccommodationsQuery) ([]model.NearbyRegion, *Page, error) {
+	qp := url.Values{}
+
+	if q.SortByDistance {
+		qp.Set("sort", "distance")
+	}
+
+	if q.Page > 0 {
+		qp.Set("page", strconv.Itoa(q.Page))
+	}
+
+	q.ListOptions.values(qp)
+
+	accommodations := make([]model.NearbyRegion, 0)
+
+	h, err := c.get(ctx, regionItemPath(regionType, geoID, "accommodations"), qp, &accommodations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return accommodations, pageOf(h), nil
+}
+
+// GetRegionAliases returns the aliases of a region by language
+func (c *Client) GetRegionAliases(ctx context.Context, regionType model.RegionType, geoID string) (map[model.Language][]string, error) {
+	aliases := map[model.Language][]string{}
+
+	if _, err := c.get(ctx, regionItemPath(regionType, geoID, "aliases"), nil, &aliases); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// AddRegionAliases adds aliases to a region and returns all of them
+func (c *Client) AddRegionAliases(ctx context.Context, regionType model.RegionType, geoID string, aliases map[model.Language][]string) (map[model.Language][]string, error) {
+	return c.writeRegionAliases(ctx, http.MethodPost, regionType, geoID, aliases)
+}
+
+// ReplaceRegionAliases replaces the aliases of a region
+func (c *Client) ReplaceRegionAliases(ctx context.Context, regionType model.RegionType, geoID string, aliases map[model.Language][]string) (map[model.Language][]string, error) {
+	return c.writeRegionAliases(ctx, http.MethodPut, regionType, geoID, aliases)
+}
+
+func (c *Client) writeRegionAliases(ctx context.Context, method string, regionType model.RegionType, geoID string, aliases map[model.Language][]string) (map[model.Language][]string, error) {
+	result := map[model.Language][]string{}
+
+	if err := c.send(ctx, method, regionItemPath(regionType, geoID, "aliases"), aliases, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRegionAlias removes an alias of a region and returns the remaining ones
+func (c *Client) DeleteRegionAlias(ctx context.Context, regionType model.RegionType, geoID string, language model.Language, alias string) (map[model.Language][]string, error) {
+	result := map[model.Language][]string{}
+
+	if err := c.send(ctx, http.MethodDelete, regionItemPath(regionType, geoID, "aliases", string(language), alias), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// NearbyQuery finds the regions in a radius, in km, of a point
+type NearbyQuery struct {
+	Latitude      float64
+	Longitude     float64
+	RadiusKm      float64
+	MinDistanceKm float64
+	Types         []model.RegionType
+	Limit         int
+}
+
+// GetNearbyRegions returns the regions in a radius sorted by distance
+func (c *Client) GetNearbyRegions(ctx context.Context, q NearbyQuery) ([]model.NearbyRegion, error) {
+	qp := url.Values{}
+	qp.Set("latitude", formatFloat(q.Latitude))
+	qp.Set("longitude", formatFloat(q.Longitude))
+	qp.Set("radius", formatFloat(q.RadiusKm))
+
+	if q.MinDistanceKm > 0 {
+		qp.Set("min_distance", formatFloat(q.MinDistanceKm))
+	}
+
+	if len(q.Types) > 0 {
+		qp.Set("types", joinTypes(q.Types))
+	}
+
+	if q.Limit > 0 {
+		qp.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	regions := make([]model.NearbyRegion, 0)
+
+	if _, err := c.get(ctx, "/v2/regions/nearby", qp, &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+// GetIntersections returns the id and type of the regions whose polygon contains the point, of all types when there are none
+func (c *Client) GetIntersections(ctx context.Context, latitude, longitude float64, types ...model.RegionType) ([]model.GeoRegion, error) {
+	qp := url.Values{}
+	qp.Set("latitude", formatFloat(latitude))
+	qp.Set("longitude", formatFloat(longitude))
+
+	if len(types) > 0 {
+		qp.Set("region_types", joinTypes(types))
+	}
+
+	regions := make([]model.GeoRegion, 0)
+
+	if _, err := c.get(ctx, "/v2/intersections", qp, &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func joinTypes(types []model.RegionType) string {
+	parts := make([]string, 0, len(types))
+
+	for _, t := range types {
+		parts = append(parts, string(t))
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,66 @@
+// Command airports imports the airports.csv of OurAirports (https://ourairports.com/data/) into the airports table.
+// Airports are matched by IATA code, the summary of created, changed and invalid rows is printed as JSON.
+//
+//	go run ./cmd/airports -e development -file airports.csv -dry-run
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/importer"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	flags := flag.NewFlagSet("airports", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	file := flags.String("file", "", "OurAirports airports.csv")
+	dryRun := flags.Bool("dry-run", false, "print the summary without writing")
+
+	_ = flags.Parse(os.Args[1:])
+
+	if *file == "" {
+		logrus.Fatal("file is required")
+	}
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to open %s. %w", *file, err))
+	}
+
+	defer f.Close()
+
+	summary, err := importer.NewAirportImporter(repo, *dryRun).Import(f)
+	if summary == nil {
+		logrus.Fatal(err)
+	}
+
+	if err != nil {
+		// The rows before the failure were imported, print what was done
+		logrus.Error(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(summary); err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d created, %d changed, %d unchanged, %d skipped, %d invalid",
+		len(summary.Created), len(summary.Changed), summary.Unchanged, summary.Skipped, len(summary.Invalid))
+}
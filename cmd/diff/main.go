@@ -0,0 +1,117 @@
+// Command diff prints the changelog between two NDJSON exports, or between an export and the live regions
+// when -before is empty, as JSON. Export with -polygons to see the polygon changes.
+//
+//	go run ./cmd/diff -e development -before regions-2024-01.ndjson -after regions-2024-02.ndjson
+//	go run ./cmd/diff -e development -after regions.ndjson -types city,neighborhood
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/diff"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	beforeFile := flags.String("before", "", "NDJSON export before the changes, the live regions when empty")
+	afterFile := flags.String("after", "", "NDJSON export after the changes")
+	types := flags.String("types", "", "comma separated region types, the ones of the after export when empty")
+
+	_ = flags.Parse(os.Args[1:])
+
+	if *afterFile == "" {
+		logrus.Fatal("after is required")
+	}
+
+	var regionTypes []model.RegionType
+
+	if *types != "" {
+		for _, t := range strings.Split(*types, ",") {
+			rt := model.RegionType(strings.TrimSpace(t))
+
+			if !rt.IsValid() {
+				logrus.Fatalf("%s is not a valid region type", rt)
+			}
+
+			regionTypes = append(regionTypes, rt)
+		}
+	}
+
+	after, err := readSnapshot(*afterFile, regionTypes)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	var before *diff.Snapshot
+
+	if *beforeFile != "" {
+		before, err = readSnapshot(*beforeFile, regionTypes)
+	} else {
+		before, err = liveSnapshot(after, regionTypes)
+	}
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	changelog := diff.Compare(before, after)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(changelog); err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d added, %d removed, %d changed, %d unchanged", len(changelog.Added), len(changelog.Removed),
+		len(changelog.Changed), changelog.Unchanged)
+}
+
+func readSnapshot(path string, regionTypes []model.RegionType) (*diff.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s. %w", path, err)
+	}
+
+	defer f.Close()
+
+	s, err := diff.ReadSnapshot(f, regionTypes, diff.Limits{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s. %w", path, err)
+	}
+
+	return s, nil
+}
+
+// liveSnapshot reads the live regions of the types, the polygons are only read when the export has them
+func liveSnapshot(after *diff.Snapshot, regionTypes []model.RegionType) (*diff.Snapshot, error) {
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongo repository. %w", err)
+	}
+
+	defer repo.Close()
+
+	if len(regionTypes) == 0 {
+		regionTypes = after.Types()
+	}
+
+	before := diff.NewSnapshot(regionTypes)
+
+	if err = repo.ExportRegions(regionTypes, after.HasPolygons(), before.Add); err != nil {
+		return nil, err
+	}
+
+	return before, nil
+}
@@ -0,0 +1,102 @@
+// Command export writes every region of the given types as NDJSON, CSV or a GeoJSON FeatureCollection.
+//
+//	go run ./cmd/export -e development -types city,neighborhood -format geojson -polygons -out regions.geojson
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/export"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	types := flags.String("types", "", "comma separated region types, all the curated ones when empty")
+	format := flags.String("format", string(export.FormatNDJSON), "ndjson, csv or geojson")
+	polygons := flags.Bool("polygons", false, "join the polygons of the regions")
+	languages := flags.String("languages", "es,en", "comma separated languages of the csv name columns")
+	out := flags.String("out", "", "output file, stdout when empty")
+
+	_ = flags.Parse(os.Args[1:])
+
+	regionTypes := model.RegionTypes()
+
+	if *types != "" {
+		regionTypes = regionTypes[:0]
+
+		for _, t := range strings.Split(*types, ",") {
+			rt := model.RegionType(strings.TrimSpace(t))
+
+			if !rt.IsValid() {
+				logrus.Fatalf("%s is not a valid region type", rt)
+			}
+
+			regionTypes = append(regionTypes, rt)
+		}
+	}
+
+	var langs []model.Language
+
+	for _, l := range strings.Split(*languages, ",") {
+		langs = append(langs, model.Language(strings.TrimSpace(l)))
+	}
+
+	var w io.Writer = os.Stdout
+
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			logrus.Fatal(fmt.Errorf("failed to create %s. %w", *out, err))
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	buffered := bufio.NewWriter(w)
+	defer buffered.Flush()
+
+	writer, err := export.NewWriter(export.Format(*format), buffered, langs)
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	count := 0
+
+	err = repo.ExportRegions(regionTypes, *polygons, func(r *model.RegionExport) error {
+		count++
+
+		return writer.Write(r)
+	})
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d regions exported", count)
+}
@@ -0,0 +1,42 @@
+// Command geohash creates the geohash indexes and sets the geohash of the points stored before it was computed on write.
+//
+//	go run ./cmd/geohash -e development
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	flags := flag.NewFlagSet("geohash", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+
+	_ = flags.Parse(os.Args[1:])
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	if err = repo.EnsureGeohashIndexes(); err != nil {
+		logrus.Fatal(err)
+	}
+
+	count, err := repo.BackfillGeohashes()
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d regions and airports updated", count)
+}
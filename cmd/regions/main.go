@@ -0,0 +1,66 @@
+// Command regions imports a provider region dump, one JSON region per line as returned by the provider regions API,
+// into the region collections and the polygons table. Only the regions that changed are written, so the same command
+// refreshes the catalog. The summary of added, changed and removed regions is printed as JSON.
+//
+//	go run ./cmd/regions -e development -file regions.jsonl -lang es -prune
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/importer"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	flags := flag.NewFlagSet("regions", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	file := flags.String("file", "", "JSONL region dump")
+	lang := flags.String("lang", "es", "language of the names in the dump")
+	prune := flags.Bool("prune", false, "delete the regions of the imported types missing from the dump, when it has no invalid lines")
+	dryRun := flags.Bool("dry-run", false, "print the summary without writing")
+
+	_ = flags.Parse(os.Args[1:])
+
+	if *file == "" {
+		logrus.Fatal("file is required")
+	}
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to open %s. %w", *file, err))
+	}
+
+	defer f.Close()
+
+	summary, err := importer.NewRegionImporter(repo, model.Language(*lang), *prune, *dryRun).Import(f)
+	if err != nil {
+		// The regions before the failure were imported, print what was done
+		logrus.Error(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(summary); err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d added, %d changed, %d removed, %d unchanged, %d skipped, %d invalid", len(summary.Added), len(summary.Changed),
+		len(summary.Removed), summary.Unchanged, summary.Skipped, len(summary.Invalid))
+}
@@ -0,0 +1,110 @@
+// Command snapshot writes the curated regions with their polygons and the airports to a snapshot file,
+// to be loaded in memory with the snapshot package.
+//
+//	go run ./cmd/snapshot -e development -out geo.snapshot
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/basset-la/api-geo/snapshot"
+	"github.com/sirupsen/logrus"
+)
+
+// airportsPage is how many airports are read at a time
+const airportsPage = 1000
+
+func main() {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	out := flags.String("out", "", "snapshot file")
+
+	_ = flags.Parse(os.Args[1:])
+
+	if *out == "" {
+		logrus.Fatal("out is required")
+	}
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	f, err := os.Create(*out)
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create %s. %w", *out, err))
+	}
+
+	defer f.Close()
+
+	buffered := bufio.NewWriter(f)
+
+	w, err := snapshot.NewWriter(buffered)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	regions := 0
+
+	err = repo.ExportRegions(model.RegionTypes(), true, func(r *model.RegionExport) error {
+		regions++
+
+		return w.WriteRegion(r)
+	})
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	airports, err := writeAirports(repo, w)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	if err = w.Close(); err != nil {
+		logrus.Fatal(err)
+	}
+
+	if err = buffered.Flush(); err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Infof("%d regions and %d airports written to %s", regions, airports, *out)
+}
+
+func writeAirports(repo *repository.MongoRepository, w *snapshot.Writer) (int, error) {
+	count := 0
+	q := repository.QueryAirport{Limit: airportsPage}
+
+	for {
+		airports := make([]model.AirportV2, 0, airportsPage)
+
+		if err := repo.GetAirportByQuery(q, &airports); err != nil {
+			return count, err
+		}
+
+		for i := range airports {
+			if err := w.WriteAirport(&airports[i]); err != nil {
+				return count, err
+			}
+		}
+
+		count += len(airports)
+
+		if len(airports) < airportsPage {
+			return count, nil
+		}
+
+		q.After = airports[len(airports)-1].IataCode
+	}
+}
@@ -0,0 +1,142 @@
+// Command timezones imports a timezone polygon set in the format of timezone-boundary-builder
+// (a GeoJSON FeatureCollection whose features have a tzid property) into the timezones table.
+//
+//	go run ./cmd/timezones -e development -file combined.json -backfill
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type feature struct {
+	Properties struct {
+		TzID string `json:"tzid"`
+	} `json:"properties"`
+	Geometry model.Geometry `json:"geometry"`
+}
+
+func main() {
+	flags := flag.NewFlagSet("timezones", flag.ExitOnError)
+	flags.String("e", "", "environment, read by conf")
+	file := flags.String("file", "", "GeoJSON FeatureCollection with the timezone polygons")
+	backfill := flags.Bool("backfill", false, "set the timezone of the regions and airports without one")
+
+	_ = flags.Parse(os.Args[1:])
+
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable,
+		conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
+
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("failed to create mongo repository. %w", err))
+	}
+
+	defer repo.Close()
+
+	if *file != "" {
+		count, err := importTimezones(repo, *file)
+
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		logrus.Infof("%d timezones imported", count)
+	}
+
+	if *backfill {
+		count, err := repo.BackfillTimezones(model.RegionTypes())
+
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		logrus.Infof("%d regions and airports updated", count)
+	}
+}
+
+// importTimezones decodes the features one by one so the whole file is never in memory
+func importTimezones(repo *repository.MongoRepository, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s. %w", path, err)
+	}
+
+	defer f.Close()
+
+	if err = repo.EnsureTimezoneIndex(); err != nil {
+		return 0, err
+	}
+
+	decoder := json.NewDecoder(f)
+
+	if err = seekFeatures(decoder); err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for decoder.More() {
+		var ft feature
+
+		if err = decoder.Decode(&ft); err != nil {
+			return count, fmt.Errorf("failed to decode feature %d. %w", count, err)
+		}
+
+		if ft.Properties.TzID == "" {
+			logrus.Warnf("feature %d has no tzid", count)
+
+			continue
+		}
+
+		err = repo.SaveTimezoneRegion(&model.TimezoneRegion{Zone: ft.Properties.TzID, Geometry: ft.Geometry})
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// seekFeatures advances the decoder to the first element of the features array
+func seekFeatures(decoder *json.Decoder) error {
+	depth := 0
+
+	for {
+		t, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("features not found")
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read file. %w", err)
+		}
+
+		switch v := t.(type) {
+		case json.Delim:
+			if v == '{' || v == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		case string:
+			if depth == 1 && v == "features" {
+				if _, err = decoder.Token(); err != nil {
+					return fmt.Errorf("failed to read features. %w", err)
+				}
+
+				return nil
+			}
+		}
+	}
+}
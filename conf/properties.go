@@ -32,7 +32,9 @@ type Props struct {
 		DBV1                string `yaml:"dbV1"`
 		AirportsTableV1     string `yaml:"airportsTableV1"`
 		AirportsTable       string `yaml:"airportsTable"`
+		MetroAreasTable     string `yaml:"metroAreasTable"`
 		GeoCoordinatesTable string `yaml:"geoCoordinatesTable"`
+		TimezonesTable      string `yaml:"timezonesTable"`
 		CitiesTable         string `yaml:"citiesTable"`
 		CountryTable        string `yaml:"countryTable"`
 		StatesTable         string `yaml:"statesTable"`
@@ -40,4 +42,19 @@ type Props struct {
 		GeoEntitiesTable    string `yaml:"geoEntitiesTable"`
 		AccommodationTable  string `yaml:"accommodationTable"`
 	} `yaml:"mongo"`
+	Pagination struct {
+		DefaultLimit int `yaml:"defaultLimit"`
+		MaxLimit     int `yaml:"maxLimit"`
+	} `yaml:"pagination"`
+	Languages struct {
+		Fallback []string `yaml:"fallback"`
+	} `yaml:"languages"`
+	ShadowRead struct {
+		Enabled       bool    `yaml:"enabled"`
+		SampleRate    float64 `yaml:"sampleRate"`
+		MaxConcurrent int     `yaml:"maxConcurrent"`
+	} `yaml:"shadowRead"`
+	Grpc struct {
+		Port int `yaml:"port"`
+	} `yaml:"grpc"`
 }
@@ -0,0 +1,301 @@
+// Package diff compares two snapshots of the region data, e.g. an export file and the live database,
+// and lists the added and removed regions, the renamed ones, the reparented ones and the polygon changes.
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+
+	"github.com/basset-la/api-geo/export"
+	"github.com/basset-la/api-geo/model"
+)
+
+// NameChange is a name added, removed or changed in a language, the missing side is empty
+type NameChange struct {
+	Language model.Language `json:"language"`
+	Before   string         `json:"before,omitempty"`
+	After    string         `json:"after,omitempty"`
+}
+
+// AncestorsChange lists the ancestors gained and lost by a region
+type AncestorsChange struct {
+	Added   []model.Ancestor `json:"added,omitempty"`
+	Removed []model.Ancestor `json:"removed,omitempty"`
+}
+
+// PolygonChange is a change of the polygon of a region, the areas are in km²
+type PolygonChange struct {
+	BeforeAreaKm2 float64 `json:"before_area_km2"`
+	AfterAreaKm2  float64 `json:"after_area_km2"`
+	AreaDeltaKm2  float64 `json:"area_delta_km2"`
+}
+
+// RegionChange is an entry of the changelog, only the changed aspects are set
+type RegionChange struct {
+	ID        string           `json:"id"`
+	Type      model.RegionType `json:"type"`
+	Name      string           `json:"name"`
+	Names     []NameChange     `json:"names,omitempty"`
+	Ancestors *AncestorsChange `json:"ancestors,omitempty"`
+	Polygon   *PolygonChange   `json:"polygon,omitempty"`
+}
+
+// Changelog is the difference between two snapshots, sorted by type and id
+type Changelog struct {
+	Added     []RegionChange `json:"added"`
+	Removed   []RegionChange `json:"removed"`
+	Changed   []RegionChange `json:"changed"`
+	Unchanged int            `json:"unchanged"`
+}
+
+// entry is what a snapshot keeps of a region, the polygon is reduced to a hash and its area
+type entry struct {
+	id          string
+	regionType  model.RegionType
+	names       map[model.Language]string
+	ancestors   []model.Ancestor
+	polygonHash uint64
+	areaKm2     float64
+}
+
+// Snapshot indexes the regions to compare, polygons are not kept in memory
+type Snapshot struct {
+	entries  map[string]*entry
+	types    map[model.RegionType]bool
+	only     map[model.RegionType]bool
+	polygons int
+}
+
+// Limits caps the regions and polygons read into a snapshot, 0 is no limit
+type Limits struct {
+	Regions  int
+	Polygons int
+}
+
+// NewSnapshot creates an empty snapshot of the regions of the types, of all of them when there are none
+func NewSnapshot(regionTypes []model.RegionType) *Snapshot {
+	s := &Snapshot{entries: map[string]*entry{}, types: map[model.RegionType]bool{}}
+
+	if len(regionTypes) > 0 {
+		s.only = make(map[model.RegionType]bool, len(regionTypes))
+
+		for _, t := range regionTypes {
+			s.only[t] = true
+		}
+	}
+
+	return s
+}
+
+// ReadSnapshot indexes the regions of the types in an NDJSON export, all of them when there are no types.
+// It fails as soon as the snapshot goes over the limits.
+func ReadSnapshot(r io.Reader, regionTypes []model.RegionType, limits Limits) (*Snapshot, error) {
+	s := NewSnapshot(regionTypes)
+	reader := export.NewReader(r)
+
+	for {
+		region, err := reader.Read()
+
+		if errors.Is(err, io.EOF) {
+			return s, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = s.Add(region); err != nil {
+			return nil, err
+		}
+
+		if limits.Regions > 0 && len(s.entries) > limits.Regions {
+			return nil, fmt.Errorf("the snapshot has more than %d regions", limits.Regions)
+		}
+
+		if limits.Polygons > 0 && s.polygons > limits.Polygons {
+			return nil, fmt.Errorf("the snapshot has more than %d polygons", limits.Polygons)
+		}
+	}
+}
+
+// Add indexes a region, the ones of other types are ignored. It fails when the region is repeated.
+func (s *Snapshot) Add(r *model.RegionExport) error {
+	if s.only != nil && !s.only[r.Type] {
+		return nil
+	}
+
+	key := string(r.Type) + "/" + r.GeoID
+
+	if _, ok := s.entries[key]; ok {
+		return fmt.Errorf("%s %s is repeated", r.Type, r.GeoID)
+	}
+
+	e := &entry{id: r.GeoID, regionType: r.Type, names: r.Name, ancestors: r.Ancestors}
+
+	for _, p := range r.Polygons {
+		if p.Type != r.Type || p.Geometry.Type == "" {
+			continue
+		}
+
+		blob, err := json.Marshal(p.Geometry.Coordinates)
+		if err != nil {
+			return fmt.Errorf("invalid polygon of %s %s. %w", r.Type, r.GeoID, err)
+		}
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(p.Geometry.Type))
+		_, _ = h.Write(blob)
+		e.polygonHash = h.Sum64()
+		s.polygons++
+
+		// The area of a malformed polygon is left as 0, the hash still reports the change
+		if m, err := model.NewPolygonMetrics(p.Geometry); err == nil {
+			e.areaKm2 = m.AreaKm2
+		}
+
+		break
+	}
+
+	s.entries[key] = e
+	s.types[r.Type] = true
+
+	return nil
+}
+
+// Len returns the number of regions in the snapshot
+func (s *Snapshot) Len() int {
+	return len(s.entries)
+}
+
+// HasPolygons returns if any region of the snapshot has a polygon
+func (s *Snapshot) HasPolygons() bool {
+	return s.polygons > 0
+}
+
+// Types returns the region types in the snapshot, sorted
+func (s *Snapshot) Types() []model.RegionType {
+	types := make([]model.RegionType, 0, len(s.types))
+
+	for t := range s.types {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
+
+// Compare returns what changed from the before snapshot to the after one.
+// Polygons are only compared when both snapshots have them, so an export without polygons can be compared too.
+func Compare(before, after *Snapshot) *Changelog {
+	c := &Changelog{Added: []RegionChange{}, Removed: []RegionChange{}, Changed: []RegionChange{}}
+	polygons := before.HasPolygons() && after.HasPolygons()
+
+	for key, b := range before.entries {
+		if _, ok := after.entries[key]; !ok {
+			c.Removed = append(c.Removed, b.change())
+		}
+	}
+
+	for key, a := range after.entries {
+		b, ok := before.entries[key]
+
+		if !ok {
+			c.Added = append(c.Added, a.change())
+
+			continue
+		}
+
+		change := a.change()
+		change.Names = nameChanges(b.names, a.names)
+		change.Ancestors = ancestorsChange(b.ancestors, a.ancestors)
+
+		if polygons && b.polygonHash != a.polygonHash {
+			change.Polygon = &PolygonChange{BeforeAreaKm2: b.areaKm2, AfterAreaKm2: a.areaKm2, AreaDeltaKm2: a.areaKm2 - b.areaKm2}
+		}
+
+		if len(change.Names) == 0 && change.Ancestors == nil && change.Polygon == nil {
+			c.Unchanged++
+
+			continue
+		}
+
+		c.Changed = append(c.Changed, change)
+	}
+
+	sortChanges(c.Added)
+	sortChanges(c.Removed)
+	sortChanges(c.Changed)
+
+	return c
+}
+
+func (e *entry) change() RegionChange {
+	return RegionChange{ID: e.id, Type: e.regionType, Name: model.LocalizedName(e.names, nil)}
+}
+
+func nameChanges(before, after map[model.Language]string) []NameChange {
+	changes := make([]NameChange, 0)
+
+	for l, b := range before {
+		if a := after[l]; a != b {
+			changes = append(changes, NameChange{Language: l, Before: b, After: a})
+		}
+	}
+
+	for l, a := range after {
+		if _, ok := before[l]; !ok && a != "" {
+			changes = append(changes, NameChange{Language: l, After: a})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Language < changes[j].Language })
+
+	return changes
+}
+
+func ancestorsChange(before, after []model.Ancestor) *AncestorsChange {
+	inBefore := make(map[model.Ancestor]bool, len(before))
+	for _, a := range before {
+		inBefore[a] = true
+	}
+
+	inAfter := make(map[model.Ancestor]bool, len(after))
+	for _, a := range after {
+		inAfter[a] = true
+	}
+
+	change := AncestorsChange{}
+
+	for _, a := range after {
+		if !inBefore[a] {
+			change.Added = append(change.Added, a)
+		}
+	}
+
+	for _, a := range before {
+		if !inAfter[a] {
+			change.Removed = append(change.Removed, a)
+		}
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return nil
+	}
+
+	return &change
+}
+
+func sortChanges(changes []RegionChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+
+		return changes[i].ID < changes[j].ID
+	})
+}
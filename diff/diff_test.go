@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const beforeNDJSON = `{"id":"178","type":"country","name":{"es":"Argentina"},"center":{"longitude":-63.6,"latitude":-38.4},"ancestors":[],"descendants":{}}
+{"id":"6139184","type":"neighborhood","name":{"es":"Palermo","en":"Palermo"},"center":{"longitude":-58.42,"latitude":-34.58},"ancestors":[{"id":"178","type":"country"}],"descendants":{},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}
+{"id":"6139185","type":"neighborhood","name":{"es":"Belgrano"},"center":{"longitude":-58.45,"latitude":-34.56},"ancestors":[{"id":"178","type":"country"}],"descendants":{}}
+`
+
+const afterNDJSON = `{"id":"178","type":"country","name":{"es":"Argentina"},"center":{"longitude":-63.6,"latitude":-38.4},"ancestors":[],"descendants":{}}
+{"id":"6139184","type":"neighborhood","name":{"es":"Palermo Soho","pt":"Palermo"},"center":{"longitude":-58.42,"latitude":-34.58},"ancestors":[{"id":"602962","type":"city"}],"descendants":{},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,1],[0,1],[0,0]]]}}
+{"id":"6139186","type":"neighborhood","name":{"es":"Recoleta"},"center":{"longitude":-58.39,"latitude":-34.58},"ancestors":[{"id":"178","type":"country"}],"descendants":{}}
+`
+
+func TestCompare(t *testing.T) {
+	// Given
+	before, err := ReadSnapshot(strings.NewReader(beforeNDJSON), nil, Limits{})
+	require.NoError(t, err)
+	after, err := ReadSnapshot(strings.NewReader(afterNDJSON), nil, Limits{})
+	require.NoError(t, err)
+
+	// When
+	c := Compare(before, after)
+
+	// Then
+	assert.Equal(t, []RegionChange{{ID: "6139186", Type: model.RegionTypeNeighborhood, Name: "Recoleta"}}, c.Added)
+	assert.Equal(t, []RegionChange{{ID: "6139185", Type: model.RegionTypeNeighborhood, Name: "Belgrano"}}, c.Removed)
+	assert.Equal(t, 1, c.Unchanged)
+	require.Len(t, c.Changed, 1)
+
+	change := c.Changed[0]
+	assert.Equal(t, []NameChange{
+		{Language: "en", Before: "Palermo"},
+		{Language: "es", Before: "Palermo", After: "Palermo Soho"},
+		{Language: "pt", After: "Palermo"},
+	}, change.Names)
+	assert.Equal(t, &AncestorsChange{
+		Added:   []model.Ancestor{{ID: "602962", Type: model.RegionTypeCity}},
+		Removed: []model.Ancestor{{ID: "178", Type: model.RegionTypeCountry}},
+	}, change.Ancestors)
+	require.NotNil(t, change.Polygon)
+	assert.InDelta(t, 12364, change.Polygon.BeforeAreaKm2, 10)
+	assert.InDelta(t, change.Polygon.BeforeAreaKm2, change.Polygon.AreaDeltaKm2, 10)
+}
+
+func TestSnapshotRepeatedRegion(t *testing.T) {
+	_, err := ReadSnapshot(strings.NewReader(beforeNDJSON+beforeNDJSON), nil, Limits{})
+	assert.Error(t, err)
+}
+
+func TestSnapshotLimits(t *testing.T) {
+	_, err := ReadSnapshot(strings.NewReader(beforeNDJSON), nil, Limits{Regions: 2})
+	assert.EqualError(t, err, "the snapshot has more than 2 regions")
+
+	others := strings.NewReplacer(`"178"`, `"179"`, "6139184", "6139194", "6139186", "6139196").Replace(afterNDJSON)
+
+	_, err = ReadSnapshot(strings.NewReader(beforeNDJSON+others), nil, Limits{Regions: 6, Polygons: 1})
+	assert.EqualError(t, err, "the snapshot has more than 1 polygons")
+
+	_, err = ReadSnapshot(strings.NewReader(beforeNDJSON+others), nil, Limits{Regions: 6, Polygons: 2})
+	assert.NoError(t, err)
+}
+
+func TestSnapshotTypes(t *testing.T) {
+	s, err := ReadSnapshot(strings.NewReader(beforeNDJSON), nil, Limits{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.HasPolygons())
+	assert.Equal(t, []model.RegionType{model.RegionTypeCountry, model.RegionTypeNeighborhood}, s.Types())
+
+	s, err = ReadSnapshot(strings.NewReader(beforeNDJSON), []model.RegionType{model.RegionTypeCountry}, Limits{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, s.Len())
+	assert.False(t, s.HasPolygons())
+}
+
+func TestCompareWithoutPolygons(t *testing.T) {
+	// Given
+	before, err := ReadSnapshot(strings.NewReader(beforeNDJSON), nil, Limits{})
+	require.NoError(t, err)
+	after, err := ReadSnapshot(strings.NewReader(strings.Replace(beforeNDJSON,
+		`,"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`, "", 1)), nil, Limits{})
+	require.NoError(t, err)
+
+	// When
+	c := Compare(before, after)
+
+	// Then
+	assert.Empty(t, c.Changed)
+	assert.Equal(t, 3, c.Unchanged)
+}
@@ -0,0 +1,218 @@
+package errors
+
+import (
+	pkgErrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Code identifies an error for the clients of the api, it does not change when the message does
+type Code string
+
+const (
+	CodeInvalidParameter   Code = "INVALID_PARAMETER"
+	CodeMissingParameter   Code = "MISSING_PARAMETER"
+	CodeInvalidCoordinates Code = "INVALID_COORDINATES"
+	CodeInvalidRegionType  Code = "INVALID_REGION_TYPE"
+	CodeInvalidBody        Code = "INVALID_BODY"
+	CodeInvalidGeometry    Code = "INVALID_GEOMETRY"
+	CodeMissingPolygon     Code = "MISSING_POLYGON"
+	CodeMethodNotAllowed   Code = "METHOD_NOT_ALLOWED"
+	CodeInvalidPayload     Code = "INVALID_PAYLOAD"
+	CodeInvalidLanguage    Code = "INVALID_LANGUAGE"
+	CodeInvalidCountryCode Code = "INVALID_COUNTRY_CODE"
+	CodeInvalidIATACode    Code = "INVALID_IATA_CODE"
+	CodeInvalidICAOCode    Code = "INVALID_ICAO_CODE"
+	CodeInvalidAncestor    Code = "INVALID_ANCESTOR"
+
+	CodeNotFound              Code = "NOT_FOUND"
+	CodeRegionNotFound        Code = "REGION_NOT_FOUND"
+	CodePolygonNotFound       Code = "POLYGON_NOT_FOUND"
+	CodeAirportNotFound       Code = "AIRPORT_NOT_FOUND"
+	CodeMetroAreaNotFound     Code = "METRO_AREA_NOT_FOUND"
+	CodeCustomAreaNotFound    Code = "CUSTOM_AREA_NOT_FOUND"
+	CodeAccommodationNotFound Code = "ACCOMMODATION_NOT_FOUND"
+	CodeTimezoneNotFound      Code = "TIMEZONE_NOT_FOUND"
+
+	CodePolygonExists   Code = "POLYGON_EXISTS"
+	CodeMetroAreaExists Code = "METRO_AREA_EXISTS"
+
+	CodeInternal Code = "INTERNAL_ERROR"
+)
+
+// statuses is the HTTP status of each code, codes missing here are internal errors
+var statuses = map[Code]int{
+	CodeInvalidParameter:   http.StatusBadRequest,
+	CodeMissingParameter:   http.StatusBadRequest,
+	CodeInvalidCoordinates: http.StatusBadRequest,
+	CodeInvalidRegionType:  http.StatusBadRequest,
+	CodeInvalidBody:        http.StatusBadRequest,
+	CodeInvalidGeometry:    http.StatusUnprocessableEntity,
+	CodeMissingPolygon:     http.StatusUnprocessableEntity,
+	CodeMethodNotAllowed:   http.StatusMethodNotAllowed,
+	CodeInvalidPayload:     http.StatusBadRequest,
+	CodeInvalidLanguage:    http.StatusBadRequest,
+	CodeInvalidCountryCode: http.StatusBadRequest,
+	CodeInvalidIATACode:    http.StatusBadRequest,
+	CodeInvalidICAOCode:    http.StatusBadRequest,
+	CodeInvalidAncestor:    http.StatusBadRequest,
+
+	CodeNotFound:              http.StatusNotFound,
+	CodeRegionNotFound:        http.StatusNotFound,
+	CodePolygonNotFound:       http.StatusNotFound,
+	CodeAirportNotFound:       http.StatusNotFound,
+	CodeMetroAreaNotFound:     http.StatusNotFound,
+	CodeCustomAreaNotFound:    http.StatusNotFound,
+	CodeAccommodationNotFound: http.StatusNotFound,
+	CodeTimezoneNotFound:      http.StatusNotFound,
+
+	CodePolygonExists:   http.StatusConflict,
+	CodeMetroAreaExists: http.StatusConflict,
+}
+
+// Status returns the HTTP status of the code
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Error is an error of the catalogue, it is the body of the error responses
+type Error struct {
+	Code    Code                   `json:"code"`
+	Message string                 `json:"message"`
+	Field   string                 `json:"field,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Fields are the errors of each field of an INVALID_PAYLOAD error
+	Fields []*Error `json:"fields,omitempty"`
+	cause  error
+}
+
+// New creates an error with a message formatted as fmt.Errorf, a %w verb keeps the wrapped error as its cause
+func New(code Code, format string, args ...interface{}) *Error {
+	err := fmt.Errorf(format, args...)
+
+	return &Error{Code: code, Message: err.Error(), cause: pkgErrors.Unwrap(err)}
+}
+
+// Invalid creates an INVALID_PARAMETER error of a field
+func Invalid(field, format string, args ...interface{}) *Error {
+	return New(CodeInvalidParameter, format, args...).WithField(field)
+}
+
+// Validation returns an INVALID_PAYLOAD error with the errors of each field of a payload, nil when there are none
+func Validation(fields []*Error) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		messages = append(messages, f.Message)
+	}
+
+	return &Error{Code: CodeInvalidPayload, Message: strings.Join(messages, "; "), Fields: fields}
+}
+
+// WithField sets the parameter or body field of the error, the message is prefixed with it
+func (e *Error) WithField(field string) *Error {
+	e.Field = field
+	e.Message = "[" + field + "] " + e.Message
+
+	return e
+}
+
+// WithDetail adds a value that helps to fix the error, e.g. the accepted range of a parameter
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	if e.Details == nil {
+		e.Details = map[string]interface{}{}
+	}
+
+	e.Details[key] = value
+
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches the not found errors with ErrEntityNotFound and the missing parameters with ErrMissingParameters
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrEntityNotFound:
+		return e.Status() == http.StatusNotFound
+	case ErrMissingParameters:
+		return e.Code == CodeMissingParameter
+	}
+
+	return false
+}
+
+// Status returns the HTTP status of the error
+func (e *Error) Status() int {
+	return e.Code.Status()
+}
+
+// From returns the catalogue error of err. Errors out of the catalogue are internal,
+// their message is replaced so that database errors are not sent to the clients.
+func From(err error) *Error {
+	var e *Error
+
+	if pkgErrors.As(err, &e) {
+		return e
+	}
+
+	switch {
+	case pkgErrors.Is(err, ErrEntityNotFound):
+		return &Error{Code: CodeNotFound, Message: "not found", cause: err}
+	case pkgErrors.Is(err, ErrMissingParameters):
+		return &Error{Code: CodeMissingParameter, Message: "missing parameters", cause: err}
+	}
+
+	return &Error{Code: CodeInternal, Message: "internal error", cause: err}
+}
+
+// BadRequest returns the catalogue error of an invalid request. The field of errors out of the catalogue
+// is read from their "[field] message" prefix.
+func BadRequest(err error) *Error {
+	var e *Error
+
+	if pkgErrors.As(err, &e) {
+		return e
+	}
+
+	if pkgErrors.Is(err, ErrMissingParameters) {
+		return &Error{Code: CodeMissingParameter, Message: err.Error(), cause: err}
+	}
+
+	e = &Error{Code: CodeInvalidParameter, Message: err.Error(), cause: err}
+
+	if strings.HasPrefix(e.Message, "[") {
+		if end := strings.Index(e.Message, "]"); end > 1 {
+			e.Field = e.Message[1:end]
+		}
+	}
+
+	return e
+}
+
+// Unprocessable returns the catalogue error of a request that is valid but can not be applied, an INVALID_GEOMETRY
+// error for errors out of the catalogue
+func Unprocessable(err error) *Error {
+	var e *Error
+
+	if pkgErrors.As(err, &e) {
+		return e
+	}
+
+	return &Error{Code: CodeInvalidGeometry, Message: err.Error(), cause: err}
+}
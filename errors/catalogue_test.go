@@ -0,0 +1,77 @@
+package errors
+
+import (
+	pkgErrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, CodeRegionNotFound.Status())
+	assert.Equal(t, http.StatusBadRequest, CodeInvalidCoordinates.Status())
+	assert.Equal(t, http.StatusUnprocessableEntity, CodeMissingPolygon.Status())
+	assert.Equal(t, http.StatusConflict, CodePolygonExists.Status())
+	assert.Equal(t, http.StatusConflict, CodeMetroAreaExists.Status())
+	assert.Equal(t, http.StatusInternalServerError, CodeInternal.Status())
+	assert.Equal(t, http.StatusInternalServerError, Code("UNKNOWN").Status())
+}
+
+func TestNew(t *testing.T) {
+	cause := pkgErrors.New("latitude out of range")
+
+	err := New(CodeInvalidCoordinates, "must be a valid point. %w", cause).WithField("latitude").WithDetail("max", 90)
+
+	assert.Equal(t, "[latitude] must be a valid point. latitude out of range", err.Error())
+	assert.Equal(t, "latitude", err.Field)
+	assert.Equal(t, map[string]interface{}{"max": 90}, err.Details)
+	assert.True(t, pkgErrors.Is(err, cause))
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestIs(t *testing.T) {
+	assert.True(t, pkgErrors.Is(New(CodeAirportNotFound, "airport not found"), ErrEntityNotFound))
+	assert.False(t, pkgErrors.Is(Invalid("limit", "must be positive"), ErrEntityNotFound))
+	assert.True(t, pkgErrors.Is(New(CodeMissingParameter, "is required"), ErrMissingParameters))
+}
+
+func TestFrom(t *testing.T) {
+	e := New(CodeRegionNotFound, "city not found")
+
+	assert.Same(t, e, From(fmt.Errorf("lookup failed. %w", e)))
+
+	notFound := From(fmt.Errorf("failed to get region. %w", ErrEntityNotFound))
+
+	assert.Equal(t, CodeNotFound, notFound.Code)
+	assert.Equal(t, http.StatusNotFound, notFound.Status())
+
+	mongo := pkgErrors.New("no reachable servers")
+	internal := From(mongo)
+
+	assert.Equal(t, CodeInternal, internal.Code)
+	assert.Equal(t, "internal error", internal.Message)
+	assert.True(t, pkgErrors.Is(internal, mongo))
+}
+
+func TestBadRequest(t *testing.T) {
+	e := BadRequest(fmt.Errorf("[limit] must be a number between 1 and 100"))
+
+	assert.Equal(t, CodeInvalidParameter, e.Code)
+	assert.Equal(t, "limit", e.Field)
+	assert.Equal(t, "[limit] must be a number between 1 and 100", e.Message)
+
+	e = BadRequest(pkgErrors.New("invalid character 'x' looking for beginning of value"))
+
+	assert.Equal(t, CodeInvalidParameter, e.Code)
+	assert.Empty(t, e.Field)
+
+	assert.Equal(t, CodeMissingParameter, BadRequest(ErrMissingParameters).Code)
+	assert.Equal(t, CodeInvalidRegionType, BadRequest(New(CodeInvalidRegionType, "x is not a valid region type")).Code)
+}
+
+func TestUnprocessable(t *testing.T) {
+	assert.Equal(t, CodeInvalidGeometry, Unprocessable(pkgErrors.New("polygon is not closed")).Code)
+	assert.Equal(t, CodeMissingPolygon, Unprocessable(New(CodeMissingPolygon, "region 2 has no polygon")).Code)
+}
@@ -0,0 +1,196 @@
+// Package export writes regions as NDJSON, CSV or a GeoJSON FeatureCollection one by one,
+// so a dataset of any size is exported with constant memory.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// Format of an export
+type Format string
+
+// Supported formats
+const (
+	FormatNDJSON  Format = "ndjson"
+	FormatCSV     Format = "csv"
+	FormatGeoJSON Format = "geojson"
+)
+
+// ContentType returns the media type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatGeoJSON:
+		return "application/geo+json"
+	}
+
+	return "application/x-ndjson"
+}
+
+// Writer writes regions in a format, Close must be called to complete the output
+type Writer interface {
+	Write(r *model.RegionExport) error
+	Close() error
+}
+
+// NewWriter returns a writer for the format. The CSV format has a name column for each language.
+func NewWriter(format Format, w io.Writer, languages []model.Language) (Writer, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		if len(languages) == 0 {
+			return nil, fmt.Errorf("csv exports need at least one language")
+		}
+
+		return &csvWriter{writer: csv.NewWriter(w), languages: languages}, nil
+	case FormatGeoJSON:
+		return &geojsonWriter{w: w}, nil
+	}
+
+	return nil, fmt.Errorf("%s is not a valid format, use ndjson, csv or geojson", format)
+}
+
+// ndjsonRegion is a region with its geometry, when it was exported
+type ndjsonRegion struct {
+	model.Region
+	Geometry *geoJSONGeometry `json:"geometry,omitempty"`
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(r *model.RegionExport) error {
+	return n.encoder.Encode(ndjsonRegion{Region: r.Region, Geometry: polygonOf(r)})
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer    *csv.Writer
+	languages []model.Language
+	header    bool
+}
+
+func (c *csvWriter) Write(r *model.RegionExport) error {
+	if !c.header {
+		header := []string{"id", "type", "country_code", "timezone", "latitude", "longitude"}
+
+		for _, l := range c.languages {
+			header = append(header, "name_"+string(l))
+		}
+
+		if err := c.writer.Write(append(header, "ancestors")); err != nil {
+			return err
+		}
+
+		c.header = true
+	}
+
+	record := []string{
+		r.GeoID,
+		string(r.Type),
+		r.CountryCode,
+		r.Timezone,
+		strconv.FormatFloat(r.Center.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(r.Center.Longitude, 'f', -1, 64),
+	}
+
+	for _, l := range c.languages {
+		record = append(record, r.Name[l])
+	}
+
+	ancestors := make([]string, 0, len(r.Ancestors))
+
+	for _, a := range r.Ancestors {
+		ancestors = append(ancestors, string(a.Type)+":"+a.ID)
+	}
+
+	return c.writer.Write(append(record, strings.Join(ancestors, ";")))
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+
+	return c.writer.Error()
+}
+
+// geoJSONGeometry is a geometry with only the GeoJSON members
+type geoJSONGeometry struct {
+	Type        model.GeometryType `json:"type"`
+	Coordinates interface{}        `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string           `json:"type"`
+	ID         string           `json:"id"`
+	Geometry   *geoJSONGeometry `json:"geometry"`
+	Properties model.Region     `json:"properties"`
+}
+
+type geojsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (g *geojsonWriter) Write(r *model.RegionExport) error {
+	prefix := ","
+
+	if g.count == 0 {
+		prefix = `{"type":"FeatureCollection","features":[`
+	}
+
+	geometry := polygonOf(r)
+
+	if geometry == nil {
+		geometry = &geoJSONGeometry{Type: model.GeometryPoint, Coordinates: []float64{r.Center.Longitude, r.Center.Latitude}}
+	}
+
+	blob, err := json.Marshal(geoJSONFeature{Type: "Feature", ID: r.GeoID, Geometry: geometry, Properties: r.Region})
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(g.w, prefix); err != nil {
+		return err
+	}
+
+	g.count++
+
+	_, err = g.w.Write(blob)
+
+	return err
+}
+
+func (g *geojsonWriter) Close() error {
+	if g.count == 0 {
+		_, err := io.WriteString(g.w, `{"type":"FeatureCollection","features":[]}`)
+
+		return err
+	}
+
+	_, err := io.WriteString(g.w, "]}")
+
+	return err
+}
+
+func polygonOf(r *model.RegionExport) *geoJSONGeometry {
+	for _, p := range r.Polygons {
+		if p.Type == r.Type && p.Geometry.Type != "" {
+			return &geoJSONGeometry{Type: p.Geometry.Type, Coordinates: p.Geometry.Coordinates}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func regions() []model.RegionExport {
+	return []model.RegionExport{
+		{
+			Region: model.Region{
+				BaseRegion:  model.BaseRegion{GeoID: "6139184", Type: model.RegionTypeNeighborhood},
+				Name:        map[model.Language]string{"es": "Palermo", "en": "Palermo, Buenos Aires"},
+				CountryCode: "AR",
+				Center:      model.Center{Latitude: -34.58, Longitude: -58.42},
+				Ancestors:   []model.Ancestor{{ID: "178", Type: model.RegionTypeCountry}},
+			},
+			Polygons: []model.GeoRegion{{
+				BaseRegion: model.BaseRegion{GeoID: "6139184", Type: model.RegionTypeNeighborhood},
+				Geometry:   model.Geometry{Type: model.GeometryPolygon, Coordinates: []interface{}{}},
+			}},
+		},
+		{
+			Region: model.Region{
+				BaseRegion: model.BaseRegion{GeoID: "178", Type: model.RegionTypeCountry},
+				Name:       map[model.Language]string{"es": "Argentina"},
+			},
+		},
+	}
+}
+
+func write(t *testing.T, format Format) string {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(format, &buf, []model.Language{"es", "en"})
+	require.NoError(t, err)
+
+	for _, r := range regions() {
+		r := r
+		require.NoError(t, w.Write(&r))
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.String()
+}
+
+func TestNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, FormatNDJSON)), "\n")
+
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"geometry":{"type":"Polygon","coordinates":[]}`)
+	assert.Contains(t, lines[0], `"center":{"longitude":-58.42,"latitude":-34.58}`)
+	assert.NotContains(t, lines[1], `"geometry"`)
+}
+
+func TestCSV(t *testing.T) {
+	assert.Equal(t, "id,type,country_code,timezone,latitude,longitude,name_es,name_en,ancestors\n"+
+		"6139184,neighborhood,AR,,-34.58,-58.42,Palermo,\"Palermo, Buenos Aires\",country:178\n"+
+		"178,country,,,0,0,Argentina,,\n", write(t, FormatCSV))
+}
+
+func TestGeoJSON(t *testing.T) {
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID       string `json:"id"`
+			Geometry struct {
+				Type string `json:"type"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(write(t, FormatGeoJSON)), &collection))
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 2)
+	assert.Equal(t, "Polygon", collection.Features[0].Geometry.Type)
+	assert.Equal(t, "Point", collection.Features[1].Geometry.Type)
+}
+
+func TestInvalidFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{}, nil)
+	assert.Error(t, err)
+
+	_, err = NewWriter(FormatCSV, &bytes.Buffer{}, nil)
+	assert.Error(t, err)
+}
+
+func TestReader(t *testing.T) {
+	// Given
+	reader := NewReader(strings.NewReader(write(t, FormatNDJSON)))
+
+	// When
+	first, err := reader.Read()
+	require.NoError(t, err)
+	second, err := reader.Read()
+	require.NoError(t, err)
+	_, err = reader.Read()
+
+	// Then
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "Palermo", first.Name["es"])
+	assert.Equal(t, []model.Ancestor{{ID: "178", Type: model.RegionTypeCountry}}, first.Ancestors)
+	require.Len(t, first.Polygons, 1)
+	assert.Equal(t, model.GeometryPolygon, first.Polygons[0].Geometry.Type)
+	assert.Equal(t, model.RegionTypeCountry, second.Type)
+	assert.Empty(t, second.Polygons)
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// maxLineSize is the longest NDJSON line read, country polygons are large
+const maxLineSize = 64 * 1024 * 1024
+
+// Reader reads back the regions of an NDJSON export one by one
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewReader returns a reader of an NDJSON export
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &Reader{scanner: scanner}
+}
+
+// Read returns the next region, with its polygon when it was exported. It returns io.EOF at the end.
+func (n *Reader) Read() (*model.RegionExport, error) {
+	for n.scanner.Scan() {
+		n.line++
+
+		raw := strings.TrimSpace(n.scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		var region struct {
+			model.Region
+			Geometry *model.Geometry `json:"geometry"`
+		}
+
+		if err := json.Unmarshal([]byte(raw), &region); err != nil {
+			return nil, fmt.Errorf("invalid region at line %d. %w", n.line, err)
+		}
+
+		r := &model.RegionExport{Region: region.Region}
+
+		if region.Geometry != nil {
+			r.Polygons = []model.GeoRegion{{BaseRegion: region.BaseRegion, Geometry: *region.Geometry}}
+		}
+
+		return r, nil
+	}
+
+	if err := n.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read line %d. %w", n.line+1, err)
+	}
+
+	return nil, io.EOF
+}
@@ -1,3 +1,10 @@
 module github.com/basset-la/api-offers
 
 go 1.16
+
+require (
+	github.com/graphql-go/graphql v0.8.1
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
+)
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"strconv"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Limits of a query, checked before it runs so a deep or wide query is rejected without loading anything
+const (
+	maxQueryDepth = 8
+	maxQueryCost  = 20000
+)
+
+// complexity walks the selections of an operation with the schema types.
+// Each field costs one per parent that resolves it, so the fields inside a list multiply by its expected size.
+type complexity struct {
+	executor  *Executor
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+	depth     int
+	cost      int
+}
+
+// checkComplexity rejects a query nested deeper than maxQueryDepth or that costs more than maxQueryCost.
+// Queries that do not parse are left to graphql.Do, which reports their errors.
+func (e *Executor) checkComplexity(req Request) error {
+	document, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return nil
+	}
+
+	c := &complexity{
+		executor:  e,
+		variables: req.Variables,
+		fragments: map[string]*ast.FragmentDefinition{},
+		visiting:  map[string]bool{},
+	}
+
+	var operation *ast.OperationDefinition
+
+	for _, definition := range document.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operation == nil && (req.OperationName == "" || (d.Name != nil && d.Name.Value == req.OperationName)) {
+				operation = d
+			}
+		}
+	}
+
+	if operation == nil {
+		return nil
+	}
+
+	c.selections(operation.SelectionSet, e.schema.QueryType(), 1, 1)
+
+	if c.depth > maxQueryDepth {
+		return pkgErrors.Invalid("query", "is nested %d levels, more than %d", c.depth, maxQueryDepth).WithDetail("max", maxQueryDepth)
+	}
+
+	if c.cost > maxQueryCost {
+		return pkgErrors.Invalid("query", "costs %d, more than %d", c.cost, maxQueryCost).WithDetail("max", maxQueryCost)
+	}
+
+	return nil
+}
+
+func (c *complexity) selections(set *ast.SelectionSet, parent *graphql.Object, depth, multiplier int) {
+	if set == nil || parent == nil {
+		return
+	}
+
+	if depth > c.depth {
+		c.depth = depth
+	}
+
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			c.field(s, parent, depth, multiplier)
+		case *ast.InlineFragment:
+			c.selections(s.SelectionSet, c.condition(s.TypeCondition, parent), depth, multiplier)
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[s.Name.Value]
+
+			// Cycles are reported by the validation of graphql.Do
+			if !ok || c.visiting[s.Name.Value] {
+				continue
+			}
+
+			c.visiting[s.Name.Value] = true
+			c.selections(fragment.SelectionSet, c.condition(fragment.TypeCondition, parent), depth, multiplier)
+			c.visiting[s.Name.Value] = false
+		}
+	}
+}
+
+func (c *complexity) field(f *ast.Field, parent *graphql.Object, depth, multiplier int) {
+	// Introspection is answered from the schema without touching the repository
+	if len(f.Name.Value) > 1 && f.Name.Value[:2] == "__" {
+		return
+	}
+
+	definition, ok := parent.Fields()[f.Name.Value]
+	if !ok {
+		return
+	}
+
+	c.cost += multiplier
+
+	fieldType := definition.Type
+
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+
+	if list, ok := fieldType.(*graphql.List); ok {
+		multiplier *= c.listSize(f)
+		fieldType = list.OfType
+
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	}
+
+	if object, ok := fieldType.(*graphql.Object); ok {
+		c.selections(f.SelectionSet, object, depth+1, multiplier)
+	}
+}
+
+func (c *complexity) condition(named *ast.Named, parent *graphql.Object) *graphql.Object {
+	if named == nil {
+		return parent
+	}
+
+	object, _ := c.executor.schema.Type(named.Name.Value).(*graphql.Object)
+
+	return object
+}
+
+// listSize is the number of ids or codes asked for, or the limit of the list, capped by the max limit
+// since a bigger one is rejected by its resolver
+func (c *complexity) listSize(f *ast.Field) int {
+	size := c.executor.defaultLimit
+
+	for _, argument := range f.Arguments {
+		value := c.value(argument.Value)
+
+		switch v := value.(type) {
+		case []interface{}:
+			size = len(v)
+		case int:
+			if argument.Name.Value == "limit" {
+				size = v
+			}
+		}
+	}
+
+	if size > c.executor.maxLimit {
+		size = c.executor.maxLimit
+	}
+
+	if size < 1 {
+		size = 1
+	}
+
+	return size
+}
+
+// value returns the literal or the variable of an argument as an int or a list, nil for any other value
+func (c *complexity) value(v ast.Value) interface{} {
+	switch v := v.(type) {
+	case *ast.Variable:
+		switch variable := c.variables[v.Name.Value].(type) {
+		case float64:
+			return int(variable)
+		case int:
+			return variable
+		case []interface{}:
+			return variable
+		}
+	case *ast.IntValue:
+		if n, err := strconv.Atoi(v.Value); err == nil {
+			return n
+		}
+	case *ast.ListValue:
+		return make([]interface{}, len(v.Values))
+	}
+
+	return nil
+}
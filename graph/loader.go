@@ -0,0 +1,250 @@
+package graph
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+)
+
+// batch collects the keys asked by the resolvers of a level of the query and loads all of them
+// with a single fetch when the first result is needed. Results are kept for the whole request.
+type batch struct {
+	mu      sync.Mutex
+	fetch   func(keys []string) (map[string]interface{}, error)
+	pending []string
+	queued  map[string]bool
+	loaded  map[string]interface{}
+	failed  map[string]error
+}
+
+func newBatch(fetch func(keys []string) (map[string]interface{}, error)) *batch {
+	return &batch{fetch: fetch, queued: map[string]bool{}, loaded: map[string]interface{}{}, failed: map[string]error{}}
+}
+
+// load queues the keys and returns a thunk with their values, missing keys are returned as nil
+func (b *batch) load(keys []string) func() ([]interface{}, error) {
+	b.mu.Lock()
+
+	for _, k := range keys {
+		if _, ok := b.loaded[k]; ok || b.queued[k] {
+			continue
+		}
+
+		b.queued[k] = true
+		b.pending = append(b.pending, k)
+	}
+
+	b.mu.Unlock()
+
+	return func() ([]interface{}, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if len(b.pending) > 0 {
+			pending := b.pending
+			b.pending = nil
+
+			values, err := b.fetch(pending)
+
+			for _, k := range pending {
+				delete(b.queued, k)
+
+				if err != nil {
+					b.failed[k] = err
+
+					continue
+				}
+
+				b.loaded[k] = values[k]
+			}
+		}
+
+		result := make([]interface{}, 0, len(keys))
+
+		for _, k := range keys {
+			if err := b.failed[k]; err != nil {
+				return nil, err
+			}
+
+			result = append(result, b.loaded[k])
+		}
+
+		return result, nil
+	}
+}
+
+// loaders are the batches of a request
+type loaders struct {
+	regions  *batch
+	polygons *batch
+	airports *batch
+}
+
+func regionKey(regionType model.RegionType, geoID string) string {
+	return string(regionType) + "/" + geoID
+}
+
+func newLoaders(repo Repository) *loaders {
+	return &loaders{
+		// regions by type/id, a query per type
+		regions: newBatch(func(keys []string) (map[string]interface{}, error) {
+			byType := map[model.RegionType][]string{}
+
+			for _, k := range keys {
+				regionType, geoID := splitKey(k)
+				byType[regionType] = append(byType[regionType], geoID)
+			}
+
+			values := make(map[string]interface{}, len(keys))
+
+			for regionType, geoIDs := range byType {
+				regions := make([]model.Region, 0, len(geoIDs))
+
+				q := repository.QueryRegion{RegionType: regionType, GeoIDs: geoIDs, Limit: len(geoIDs)}
+
+				if err := repo.GetRegions(q, &regions); err != nil {
+					return nil, err
+				}
+
+				for i := range regions {
+					values[regionKey(regionType, regions[i].GeoID)] = &regions[i]
+				}
+			}
+
+			return values, nil
+		}),
+		// polygons by id, an id can have polygons of several types
+		polygons: newBatch(func(keys []string) (map[string]interface{}, error) {
+			regions, err := repo.GetGeoRegions(keys)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make(map[string]interface{}, len(keys))
+
+			for i := range regions {
+				list, _ := values[regions[i].GeoID].([]*model.GeoRegion)
+				values[regions[i].GeoID] = append(list, &regions[i])
+			}
+
+			return values, nil
+		}),
+		// airports by the type/id of their region, a query per type
+		airports: newBatch(func(keys []string) (map[string]interface{}, error) {
+			byType := map[model.RegionType][]string{}
+
+			for _, k := range keys {
+				regionType, geoID := splitKey(k)
+				byType[regionType] = append(byType[regionType], geoID)
+			}
+
+			values := make(map[string]interface{}, len(keys))
+
+			for regionType, geoIDs := range byType {
+				airports := make([]model.AirportV2, 0)
+
+				q := repository.QueryAirport{RegionType: regionType, RegionIDs: geoIDs}
+
+				if err := repo.GetAirportByQuery(q, &airports); err != nil {
+					return nil, err
+				}
+
+				for i := range airports {
+					k := regionKey(regionType, airports[i].Region.ID)
+					list, _ := values[k].([]*model.AirportV2)
+					values[k] = append(list, &airports[i])
+				}
+			}
+
+			return values, nil
+		}),
+	}
+}
+
+func splitKey(k string) (model.RegionType, string) {
+	i := strings.Index(k, "/")
+
+	return model.RegionType(k[:i]), k[i+1:]
+}
+
+// loadRegions loads regions by type and ids, the missing ones are skipped
+func (l *loaders) loadRegions(regionType model.RegionType, geoIDs []string) func() (interface{}, error) {
+	keys := make([]string, 0, len(geoIDs))
+
+	for _, id := range geoIDs {
+		keys = append(keys, regionKey(regionType, id))
+	}
+
+	return l.loadRegionKeys(keys)
+}
+
+func (l *loaders) loadRegionKeys(keys []string) func() (interface{}, error) {
+	thunk := l.regions.load(keys)
+
+	return func() (interface{}, error) {
+		values, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+
+		regions := make([]*model.Region, 0, len(values))
+
+		for _, v := range values {
+			if r, ok := v.(*model.Region); ok {
+				regions = append(regions, r)
+			}
+		}
+
+		return regions, nil
+	}
+}
+
+// loadPolygons loads the polygons of the ids with the type
+func (l *loaders) loadPolygons(regionType model.RegionType, geoIDs []string) func() ([]*model.GeoRegion, error) {
+	thunk := l.polygons.load(geoIDs)
+
+	return func() ([]*model.GeoRegion, error) {
+		values, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+
+		polygons := make([]*model.GeoRegion, 0, len(values))
+
+		for _, v := range values {
+			list, _ := v.([]*model.GeoRegion)
+
+			for _, p := range list {
+				if p.Type == regionType {
+					polygons = append(polygons, p)
+
+					break
+				}
+			}
+		}
+
+		return polygons, nil
+	}
+}
+
+// loadAirports loads the airports of a region
+func (l *loaders) loadAirports(regionType model.RegionType, geoID string) func() (interface{}, error) {
+	thunk := l.airports.load([]string{regionKey(regionType, geoID)})
+
+	return func() (interface{}, error) {
+		values, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+
+		airports, _ := values[0].([]*model.AirportV2)
+
+		if airports == nil {
+			airports = []*model.AirportV2{}
+		}
+
+		return airports, nil
+	}
+}
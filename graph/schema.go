@@ -0,0 +1,577 @@
+// Package graph serves a GraphQL schema to navigate the region hierarchy: a region resolves its ancestors,
+// descendants, airports, polygon and accommodations as nested objects, so a destination page is assembled
+// with a single query. Resolvers queue their lookups and each level of the query is loaded with one
+// repository call per kind of data, instead of one call per region.
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Repository is the part of the repository the schema is resolved with
+type Repository interface {
+	GetRegions(query repository.QueryRegion, r *[]model.Region) error
+	GetGeoRegions(geoIDs []string) ([]model.GeoRegion, error)
+	GetAirportByQuery(q repository.QueryAirport, a *[]model.AirportV2) error
+}
+
+// Request is the body of a GraphQL request
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Executor runs the queries against the repository
+type Executor struct {
+	schema       graphql.Schema
+	repo         Repository
+	defaultLimit int
+	maxLimit     int
+	fallback     []model.Language
+}
+
+type contextKey struct{}
+
+// requestState is what the resolvers of a request share
+type requestState struct {
+	loaders   *loaders
+	languages []model.Language
+}
+
+// NewExecutor builds the schema, the limits apply to the descendants and accommodations of a region,
+// names are in the fallback languages unless the query or the request asks for others
+func NewExecutor(repo Repository, defaultLimit, maxLimit int, fallback []model.Language) (*Executor, error) {
+	e := &Executor{repo: repo, defaultLimit: defaultLimit, maxLimit: maxLimit, fallback: fallback}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: e.queryType()})
+	if err != nil {
+		return nil, fmt.Errorf("invalid graphql schema. %w", err)
+	}
+
+	e.schema = schema
+
+	return e, nil
+}
+
+// Execute runs a query, the requested languages are used for the names without a language argument
+func (e *Executor) Execute(ctx context.Context, req Request, languages []model.Language) *graphql.Result {
+	if err := e.checkComplexity(req); err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{coded(gqlerrors.FormatError(err), pkgErrors.From(err))}}
+	}
+
+	state := &requestState{loaders: newLoaders(e.repo), languages: model.LanguageChain(languages, e.fallback)}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         e.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        context.WithValue(ctx, contextKey{}, state),
+	})
+
+	codeErrors(result)
+
+	return result
+}
+
+// codeErrors adds the code of the errors catalogue to the errors of the resolvers as an extension.
+// The message of internal errors is replaced, their original error is kept for logging.
+func codeErrors(result *graphql.Result) {
+	for i, formatted := range result.Errors {
+		located, ok := formatted.OriginalError().(*gqlerrors.Error)
+
+		if !ok || located.OriginalError == nil {
+			continue
+		}
+
+		result.Errors[i] = coded(formatted, pkgErrors.From(located.OriginalError))
+	}
+}
+
+func coded(formatted gqlerrors.FormattedError, e *pkgErrors.Error) gqlerrors.FormattedError {
+	extensions := map[string]interface{}{"code": e.Code}
+
+	if e.Field != "" {
+		extensions["field"] = e.Field
+	}
+
+	if len(e.Details) > 0 {
+		extensions["details"] = e.Details
+	}
+
+	formatted.Message = e.Message
+	formatted.Extensions = extensions
+
+	return formatted
+}
+
+func stateOf(p graphql.ResolveParams) *requestState {
+	return p.Context.Value(contextKey{}).(*requestState)
+}
+
+// jsonType passes the GeoJSON coordinates through as they are
+var jsonType = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Any JSON value",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} { return nil },
+})
+
+var coordinatesType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Coordinates",
+	Fields: graphql.Fields{
+		"latitude":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"longitude": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+var geometryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Geometry",
+	Fields: graphql.Fields{
+		"type": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return string(p.Source.(*model.GeoRegion).Geometry.Type), nil
+			},
+		},
+		"coordinates": &graphql.Field{
+			Type: jsonType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*model.GeoRegion).Geometry
+
+				if g.Coordinates == nil && g.Type == model.GeometryPoint {
+					return g.Point, nil
+				}
+
+				return g.Coordinates, nil
+			},
+		},
+	},
+})
+
+var accommodationType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Accommodation",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*model.GeoRegion).GeoID, nil
+			},
+		},
+		"coordinates": &graphql.Field{
+			Type: coordinatesType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*model.GeoRegion).Geometry
+
+				if point := g.Point; len(point) >= 2 {
+					return model.Coordinates{Longitude: point[0], Latitude: point[1]}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		"geohash": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*model.GeoRegion).Geohash, nil
+			},
+		},
+	},
+})
+
+// nameField resolves the name in the language argument, or in the languages of the request
+func nameField(names func(source interface{}) map[model.Language]string) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"language": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			chain := stateOf(p).languages
+
+			if l, ok := p.Args["language"].(string); ok && l != "" {
+				chain = model.LanguageChain([]model.Language{model.Language(l)}, chain)
+			}
+
+			return model.LocalizedName(names(p.Source), chain), nil
+		},
+	}
+}
+
+func (e *Executor) limitArg(p graphql.ResolveParams) (int, error) {
+	limit, ok := p.Args["limit"].(int)
+
+	if !ok {
+		return e.defaultLimit, nil
+	}
+
+	if limit < 1 || limit > e.maxLimit {
+		return 0, pkgErrors.Invalid("limit", "must be a number between 1 and %d", e.maxLimit).WithDetail("max", e.maxLimit)
+	}
+
+	return limit, nil
+}
+
+func regionTypeArg(p graphql.ResolveParams, name string) (model.RegionType, error) {
+	regionType := model.RegionType(p.Args[name].(string))
+
+	if !regionType.IsValid() || regionType == model.RegionTypeCustomArea {
+		return "", pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", regionType).WithField(name)
+	}
+
+	return regionType, nil
+}
+
+func descendantIDs(d model.Descendants, regionType model.RegionType) []string {
+	switch regionType {
+	case model.RegionTypeCity:
+		return d.Cities
+	case model.RegionTypeCountry:
+		return d.Countries
+	case model.RegionTypePOI:
+		return d.POIs
+	case model.RegionTypeHighLevelRegion:
+		return d.HighLevelRegions
+	case model.RegionTypeTrainStation:
+		return d.TrainStations
+	case model.RegionTypeMetroStation:
+		return d.MetroStations
+	case model.RegionTypeNeighborhood:
+		return d.Neighbourhoods
+	case model.RegionTypeMultiCityVicinity:
+		return d.MultiCityVicinities
+	case model.RegionTypeProvinceState:
+		return d.ProvinceStates
+	}
+
+	return nil
+}
+
+// objectTypes builds the Region and Airport types, they reference each other
+func (e *Executor) objectTypes() (*graphql.Object, *graphql.Object) {
+	airportType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Airport",
+		Fields: graphql.Fields{
+			"iataCode": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.AirportV2).IataCode, nil
+				},
+			},
+			"icaoCode": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.AirportV2).IcaoCode, nil
+				},
+			},
+			"name": nameField(func(source interface{}) map[model.Language]string {
+				return source.(*model.AirportV2).Name
+			}),
+			"countryCode": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.AirportV2).CountryCode, nil
+				},
+			},
+			"timezone": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.AirportV2).Timezone, nil
+				},
+			},
+			"coordinates": &graphql.Field{
+				Type: coordinatesType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.AirportV2).Coordinates, nil
+				},
+			},
+		},
+	})
+
+	regionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Region",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.Region).GeoID, nil
+				},
+			},
+			"type": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*model.Region).Type), nil
+				},
+			},
+			"name": nameField(func(source interface{}) map[model.Language]string {
+				return source.(*model.Region).Name
+			}),
+			"countryCode": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.Region).CountryCode, nil
+				},
+			},
+			"timezone": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*model.Region).Timezone, nil
+				},
+			},
+			"center": &graphql.Field{
+				Type: coordinatesType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(*model.Region).Center
+
+					return model.Coordinates{Longitude: c.Longitude, Latitude: c.Latitude}, nil
+				},
+			},
+			"airports": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(airportType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := p.Source.(*model.Region)
+
+					return stateOf(p).loaders.loadAirports(r.Type, r.GeoID), nil
+				},
+			},
+			"polygon": &graphql.Field{
+				Type: geometryType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := p.Source.(*model.Region)
+					thunk := stateOf(p).loaders.loadPolygons(r.Type, []string{r.GeoID})
+
+					return func() (interface{}, error) {
+						polygons, err := thunk()
+						if err != nil || len(polygons) == 0 {
+							return nil, err
+						}
+
+						return polygons[0], nil
+					}, nil
+				},
+			},
+			"accommodations": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(accommodationType))),
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, err := e.limitArg(p)
+					if err != nil {
+						return nil, err
+					}
+
+					ids := p.Source.(*model.Region).Descendants.Accommodations
+
+					if len(ids) > limit {
+						ids = ids[:limit]
+					}
+
+					thunk := stateOf(p).loaders.loadPolygons(model.RegionTypeAccommodation, ids)
+
+					return func() (interface{}, error) {
+						return thunk()
+					}, nil
+				},
+			},
+		},
+	})
+
+	airportType.AddFieldConfig("region", &graphql.Field{
+		Type: regionType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			region := p.Source.(*model.AirportV2).Region
+
+			if region.ID == "" {
+				return nil, nil
+			}
+
+			thunk := stateOf(p).loaders.loadRegions(model.RegionType(region.Type), []string{region.ID})
+
+			return func() (interface{}, error) {
+				regions, err := thunk()
+				if err != nil || len(regions.([]*model.Region)) == 0 {
+					return nil, err
+				}
+
+				return regions.([]*model.Region)[0], nil
+			}, nil
+		},
+	})
+
+	regionType.AddFieldConfig("ancestors", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(regionType))),
+		Description: "The ancestors in the order they are stored, only the ones of the type when it is set",
+		Args: graphql.FieldConfigArgument{
+			"type": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var only model.RegionType
+
+			if _, ok := p.Args["type"]; ok {
+				var err error
+
+				if only, err = regionTypeArg(p, "type"); err != nil {
+					return nil, err
+				}
+			}
+
+			keys := make([]string, 0)
+
+			for _, a := range p.Source.(*model.Region).Ancestors {
+				if only == "" || a.Type == only {
+					keys = append(keys, regionKey(a.Type, a.ID))
+				}
+			}
+
+			return stateOf(p).loaders.loadRegionKeys(keys), nil
+		},
+	})
+
+	regionType.AddFieldConfig("descendants", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(regionType))),
+		Args: graphql.FieldConfigArgument{
+			"type":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			regionType, err := regionTypeArg(p, "type")
+			if err != nil {
+				return nil, err
+			}
+
+			limit, err := e.limitArg(p)
+			if err != nil {
+				return nil, err
+			}
+
+			ids := descendantIDs(p.Source.(*model.Region).Descendants, regionType)
+
+			if len(ids) > limit {
+				ids = ids[:limit]
+			}
+
+			return stateOf(p).loaders.loadRegions(regionType, ids), nil
+		},
+	})
+
+	return regionType, airportType
+}
+
+func (e *Executor) queryType() *graphql.Object {
+	regionType, airportType := e.objectTypes()
+
+	airports := func(codes []string) ([]*model.AirportV2, error) {
+		result := make([]model.AirportV2, 0, len(codes))
+
+		if err := e.repo.GetAirportByQuery(repository.QueryAirport{IataCodes: codes}, &result); err != nil {
+			return nil, err
+		}
+
+		airports := make([]*model.AirportV2, 0, len(result))
+
+		for i := range result {
+			airports = append(airports, &result[i])
+		}
+
+		return airports, nil
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"region": &graphql.Field{
+				Type: regionType,
+				Args: graphql.FieldConfigArgument{
+					"type": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"id":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					regionType, err := regionTypeArg(p, "type")
+					if err != nil {
+						return nil, err
+					}
+
+					thunk := stateOf(p).loaders.loadRegions(regionType, []string{p.Args["id"].(string)})
+
+					return func() (interface{}, error) {
+						regions, err := thunk()
+						if err != nil || len(regions.([]*model.Region)) == 0 {
+							return nil, err
+						}
+
+						return regions.([]*model.Region)[0], nil
+					}, nil
+				},
+			},
+			"regions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(regionType))),
+				Args: graphql.FieldConfigArgument{
+					"type": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"ids":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					regionType, err := regionTypeArg(p, "type")
+					if err != nil {
+						return nil, err
+					}
+
+					ids := make([]string, 0)
+
+					for _, id := range p.Args["ids"].([]interface{}) {
+						ids = append(ids, id.(string))
+					}
+
+					if len(ids) > e.maxLimit {
+						return nil, pkgErrors.Invalid("ids", "can not have more than %d ids", e.maxLimit).WithDetail("max", e.maxLimit)
+					}
+
+					return stateOf(p).loaders.loadRegions(regionType, ids), nil
+				},
+			},
+			"airport": &graphql.Field{
+				Type: airportType,
+				Args: graphql.FieldConfigArgument{
+					"iataCode": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					result, err := airports([]string{p.Args["iataCode"].(string)})
+					if err != nil || len(result) == 0 {
+						return nil, err
+					}
+
+					return result[0], nil
+				},
+			},
+			"airports": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(airportType))),
+				Args: graphql.FieldConfigArgument{
+					"iataCodes": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					codes := make([]string, 0)
+
+					for _, c := range p.Args["iataCodes"].([]interface{}) {
+						codes = append(codes, c.(string))
+					}
+
+					if len(codes) > e.maxLimit {
+						return nil, pkgErrors.Invalid("iataCodes", "can not have more than %d codes", e.maxLimit).WithDetail("max", e.maxLimit)
+					}
+
+					return airports(codes)
+				},
+			},
+		},
+	})
+}
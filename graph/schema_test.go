@@ -0,0 +1,310 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepository struct {
+	regions  map[string]model.Region
+	polygons []model.GeoRegion
+	airports []model.AirportV2
+	calls    map[string]int
+	fail     bool
+}
+
+func (f *fakeRepository) GetRegions(query repository.QueryRegion, r *[]model.Region) error {
+	f.calls["regions"]++
+
+	if f.fail {
+		return fmt.Errorf("connection refused")
+	}
+
+	for _, id := range query.GeoIDs {
+		if region, ok := f.regions[regionKey(query.RegionType, id)]; ok {
+			*r = append(*r, region)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeRepository) GetGeoRegions(geoIDs []string) ([]model.GeoRegion, error) {
+	f.calls["polygons"]++
+
+	result := make([]model.GeoRegion, 0)
+
+	for _, id := range geoIDs {
+		for _, p := range f.polygons {
+			if p.GeoID == id {
+				result = append(result, p)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeRepository) GetAirportByQuery(q repository.QueryAirport, a *[]model.AirportV2) error {
+	f.calls["airports"]++
+
+	for _, airport := range f.airports {
+		if q.RegionType != "" && airport.Region.Type != string(q.RegionType) {
+			continue
+		}
+
+		for _, id := range q.RegionIDs {
+			if airport.Region.ID == id {
+				*a = append(*a, airport)
+			}
+		}
+
+		for _, code := range q.IataCodes {
+			if airport.IataCode == code {
+				*a = append(*a, airport)
+			}
+		}
+	}
+
+	return nil
+}
+
+func region(regionType model.RegionType, id, name string, ancestors ...model.Ancestor) model.Region {
+	return model.Region{
+		BaseRegion: model.BaseRegion{GeoID: id, Type: regionType},
+		Name:       map[model.Language]string{"es": name, "en": name + " (en)"},
+		Ancestors:  ancestors,
+	}
+}
+
+func newFake() *fakeRepository {
+	country := model.Ancestor{ID: "178", Type: model.RegionTypeCountry}
+	continent := model.Ancestor{ID: "6", Type: model.RegionTypeContinent}
+
+	argentina := region(model.RegionTypeCountry, "178", "Argentina", continent)
+	argentina.Descendants.Cities = []string{"1", "2", "3"}
+
+	buenosAires := region(model.RegionTypeCity, "2", "Buenos Aires", country, continent)
+	buenosAires.Descendants.Neighbourhoods = []string{"20", "21"}
+	buenosAires.Descendants.Accommodations = []string{"900", "901"}
+
+	f := &fakeRepository{
+		regions: map[string]model.Region{},
+		calls:   map[string]int{},
+		polygons: []model.GeoRegion{
+			{BaseRegion: model.BaseRegion{GeoID: "2", Type: model.RegionTypeCity}, Geometry: model.Geometry{Type: model.GeometryPolygon, Coordinates: []interface{}{}}},
+			{BaseRegion: model.BaseRegion{GeoID: "900", Type: model.RegionTypeAccommodation}, Geometry: model.Geometry{Type: model.GeometryPoint, Point: []float64{-58.38, -34.6}}},
+			{BaseRegion: model.BaseRegion{GeoID: "901", Type: model.RegionTypeAccommodation}, Geometry: model.Geometry{Type: model.GeometryPoint, Point: []float64{-58.4, -34.61}}},
+		},
+		airports: []model.AirportV2{
+			{IataCode: "AEP", Region: model.AirportRegion{ID: "2", Type: "city"}},
+			{IataCode: "EZE", Region: model.AirportRegion{ID: "2", Type: "city"}},
+			{IataCode: "COR", Region: model.AirportRegion{ID: "1", Type: "city"}},
+		},
+	}
+
+	for _, r := range []model.Region{
+		argentina,
+		buenosAires,
+		region(model.RegionTypeCity, "1", "Córdoba", country, continent),
+		region(model.RegionTypeCity, "3", "Rosario", country, continent),
+		region(model.RegionTypeContinent, "6", "Sudamérica"),
+		region(model.RegionTypeNeighborhood, "20", "Palermo", model.Ancestor{ID: "2", Type: model.RegionTypeCity}),
+		region(model.RegionTypeNeighborhood, "21", "Recoleta", model.Ancestor{ID: "2", Type: model.RegionTypeCity}),
+	} {
+		f.regions[regionKey(r.Type, r.GeoID)] = r
+	}
+
+	return f
+}
+
+func execute(t *testing.T, repo Repository, query string) (map[string]interface{}, []string) {
+	e, err := NewExecutor(repo, 2, 10, []model.Language{"es"})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), Request{Query: query}, nil)
+
+	errors := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		errors = append(errors, err.Message)
+	}
+
+	blob, err := json.Marshal(result.Data)
+	require.NoError(t, err)
+
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(blob, &data))
+
+	return data, errors
+}
+
+func TestRegionHierarchyIsBatched(t *testing.T) {
+	repo := newFake()
+
+	data, errors := execute(t, repo, `{
+		region(type: "country", id: "178") {
+			name
+			ancestors { name }
+			descendants(type: "city", limit: 3) {
+				id
+				name(language: "en")
+				ancestors { id type }
+				airports { iataCode region { name } }
+			}
+		}
+	}`)
+
+	require.Empty(t, errors)
+
+	country := data["region"].(map[string]interface{})
+	assert.Equal(t, "Argentina", country["name"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Sudamérica"}}, country["ancestors"])
+
+	cities := country["descendants"].([]interface{})
+	require.Len(t, cities, 3)
+	assert.Equal(t, "Buenos Aires (en)", cities[1].(map[string]interface{})["name"])
+	assert.Len(t, cities[1].(map[string]interface{})["ancestors"], 2)
+	assert.Len(t, cities[1].(map[string]interface{})["airports"], 2)
+	assert.Empty(t, cities[2].(map[string]interface{})["airports"])
+
+	// The country, then its continent and its cities with a query per type. The ancestors of the cities
+	// and the regions of the airports were already loaded.
+	assert.Equal(t, 3, repo.calls["regions"])
+	assert.Equal(t, 1, repo.calls["airports"])
+}
+
+func TestAirportsOfRegionsSharingAnID(t *testing.T) {
+	repo := newFake()
+	repo.regions[regionKey(model.RegionTypeMultiCityVicinity, "2")] = region(model.RegionTypeMultiCityVicinity, "2", "Gran Buenos Aires")
+	repo.airports = append(repo.airports, model.AirportV2{IataCode: "FDO", Region: model.AirportRegion{ID: "2", Type: "multi_city_vicinity"}})
+
+	data, errors := execute(t, repo, `{
+		city: region(type: "city", id: "2") { airports { iataCode } }
+		vicinity: region(type: "multi_city_vicinity", id: "2") { airports { iataCode } }
+	}`)
+
+	require.Empty(t, errors)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"iataCode": "AEP"},
+		map[string]interface{}{"iataCode": "EZE"},
+	}, data["city"].(map[string]interface{})["airports"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"iataCode": "FDO"},
+	}, data["vicinity"].(map[string]interface{})["airports"])
+
+	// a query per region type
+	assert.Equal(t, 2, repo.calls["airports"])
+}
+
+func TestRegionPolygonAndAccommodations(t *testing.T) {
+	repo := newFake()
+
+	data, errors := execute(t, repo, `{
+		regions(type: "city", ids: ["2", "1", "404"]) {
+			id
+			polygon { type }
+			accommodations { id coordinates { latitude longitude } }
+			descendants(type: "neighborhood") { name }
+		}
+	}`)
+
+	require.Empty(t, errors)
+
+	cities := data["regions"].([]interface{})
+	require.Len(t, cities, 2)
+
+	buenosAires := cities[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "Polygon"}, buenosAires["polygon"])
+	assert.Len(t, buenosAires["descendants"], 2)
+	assert.Equal(t, map[string]interface{}{
+		"id":          "900",
+		"coordinates": map[string]interface{}{"latitude": -34.6, "longitude": -58.38},
+	}, buenosAires["accommodations"].([]interface{})[0])
+
+	assert.Nil(t, cities[1].(map[string]interface{})["polygon"])
+	assert.Equal(t, 1, repo.calls["polygons"])
+}
+
+func TestInvalidArguments(t *testing.T) {
+	_, errors := execute(t, newFake(), `{ region(type: "galaxy", id: "1") { id } }`)
+	assert.Equal(t, []string{"[type] galaxy is not a valid region type"}, errors)
+
+	_, errors = execute(t, newFake(), `{ region(type: "country", id: "178") { descendants(type: "city", limit: 50) { id } } }`)
+	assert.Equal(t, []string{"[limit] must be a number between 1 and 10"}, errors)
+}
+
+func TestRepositoryErrors(t *testing.T) {
+	repo := newFake()
+	repo.fail = true
+
+	data, errors := execute(t, repo, `{ a: region(type: "city", id: "1") { id } b: region(type: "city", id: "2") { id } }`)
+
+	assert.Equal(t, []string{"internal error", "internal error"}, errors)
+	assert.Nil(t, data["a"])
+	assert.Equal(t, 1, repo.calls["regions"])
+}
+
+func TestAirport(t *testing.T) {
+	data, errors := execute(t, newFake(), `{ airport(iataCode: "EZE") { iataCode region { id } } missing: airport(iataCode: "XXX") { iataCode } }`)
+
+	require.Empty(t, errors)
+	assert.Equal(t, map[string]interface{}{"iataCode": "EZE", "region": map[string]interface{}{"id": "2"}}, data["airport"])
+	assert.Nil(t, data["missing"])
+}
+
+func TestErrorCodes(t *testing.T) {
+	repo := newFake()
+	repo.fail = true
+
+	e, err := NewExecutor(repo, 2, 10, []model.Language{"es"})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), Request{Query: `{ a: region(type: "galaxy", id: "1") { id } b: region(type: "city", id: "2") { id } }`}, nil)
+
+	codes := map[string]interface{}{}
+	for _, err := range result.Errors {
+		codes[err.Message] = err.Extensions["code"]
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"[type] galaxy is not a valid region type": pkgErrors.CodeInvalidRegionType,
+		"internal error": pkgErrors.CodeInternal,
+	}, codes)
+}
+
+func TestQueryComplexity(t *testing.T) {
+	repo := newFake()
+
+	deep := `{ region(type: "country", id: "178") { ` + strings.Repeat(`descendants(type: "city", limit: 1) { `, 7) + `id` + strings.Repeat(` }`, 7) + ` } }`
+
+	data, errors := execute(t, repo, deep)
+	assert.Equal(t, []string{"[query] is nested 9 levels, more than 8"}, errors)
+	assert.Nil(t, data)
+	assert.Empty(t, repo.calls)
+
+	wide := `query ($ids: [ID!]!) { regions(type: "country", ids: $ids) { ...cities } }
+		fragment cities on Region { descendants(type: "city", limit: 10) { descendants(type: "city", limit: 10) { descendants(type: "city") { id name } } } }`
+
+	e, err := NewExecutor(repo, 10, 10, []model.Language{"es"})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), Request{Query: wide, Variables: map[string]interface{}{"ids": []interface{}{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}}}, nil)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "[query] costs 21111, more than 20000", result.Errors[0].Message)
+	assert.Equal(t, pkgErrors.CodeInvalidParameter, result.Errors[0].Extensions["code"])
+	assert.Empty(t, repo.calls)
+
+	_, errors = execute(t, repo, `{ regions(type: "city", ids: ["1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"]) { id } }`)
+	assert.Equal(t, []string{"[ids] can not have more than 10 ids"}, errors)
+}
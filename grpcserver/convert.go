@@ -0,0 +1,209 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/pb/geov2"
+)
+
+func names(n map[model.Language]string) map[string]string {
+	if len(n) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(n))
+
+	for l, name := range n {
+		result[string(l)] = name
+	}
+
+	return result
+}
+
+func regionToPB(r *model.Region) *geov2.Region {
+	region := &geov2.Region{
+		Id:          r.GeoID,
+		Type:        string(r.Type),
+		Name:        names(r.Name),
+		CountryCode: r.CountryCode,
+		Timezone:    r.Timezone,
+		Center:      &geov2.Center{Longitude: r.Center.Longitude, Latitude: r.Center.Latitude},
+		Ancestors:   make([]*geov2.Ancestor, 0, len(r.Ancestors)),
+		Descendants: &geov2.Descendants{
+			Cities:              r.Descendants.Cities,
+			Countries:           r.Descendants.Countries,
+			PointsOfInterest:    r.Descendants.POIs,
+			HighLevelRegions:    r.Descendants.HighLevelRegions,
+			TrainStations:       r.Descendants.TrainStations,
+			MetroStations:       r.Descendants.MetroStations,
+			Neighborhoods:       r.Descendants.Neighbourhoods,
+			MultiCityVicinities: r.Descendants.MultiCityVicinities,
+			ProvinceStates:      r.Descendants.ProvinceStates,
+			Accommodations:      r.Descendants.Accommodations,
+		},
+	}
+
+	if len(r.Aliases) > 0 {
+		region.Aliases = make(map[string]*geov2.Aliases, len(r.Aliases))
+
+		for l, aliases := range r.Aliases {
+			region.Aliases[string(l)] = &geov2.Aliases{Values: aliases}
+		}
+	}
+
+	for _, a := range r.Ancestors {
+		region.Ancestors = append(region.Ancestors, &geov2.Ancestor{Id: a.ID, Type: string(a.Type)})
+	}
+
+	return region
+}
+
+func airportToPB(a *model.AirportV2) *geov2.Airport {
+	return &geov2.Airport{
+		Id:          a.ID.Hex(),
+		IataCode:    a.IataCode,
+		IcaoCode:    a.IcaoCode,
+		Name:        names(a.Name),
+		CountryCode: a.CountryCode,
+		Timezone:    a.Timezone,
+		Coordinates: &geov2.Coordinates{Longitude: a.Coordinates.Longitude, Latitude: a.Coordinates.Latitude},
+		Geohash:     a.Geohash,
+		Region:      &geov2.AirportRegion{Id: a.Region.ID, Type: a.Region.Type, Name: names(a.Region.Name)},
+	}
+}
+
+func geoRegionToPB(r *model.GeoRegion) (*geov2.GeoRegion, error) {
+	geometry, err := geometryToPB(r.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid geometry of region %s. %w", r.GeoID, err)
+	}
+
+	return &geov2.GeoRegion{Id: r.GeoID, Type: string(r.Type), Geometry: geometry, Geohash: r.Geohash}, nil
+}
+
+// geometryToPB flattens the positions of the rings, a geometry without type is returned as nil
+func geometryToPB(g model.Geometry) (*geov2.Geometry, error) {
+	switch g.Type {
+	case "":
+		return nil, nil
+	case model.GeometryPoint:
+		point := g.Point
+
+		if g.Coordinates != nil {
+			point = make([]float64, 0, 2)
+
+			for _, c := range g.Coordinates {
+				v, ok := c.(float64)
+				if !ok {
+					return nil, fmt.Errorf("invalid point coordinates")
+				}
+
+				point = append(point, v)
+			}
+		}
+
+		if len(point) < 2 {
+			return nil, fmt.Errorf("invalid point coordinates")
+		}
+
+		return &geov2.Geometry{Type: string(g.Type), Point: point[:2]}, nil
+	case model.GeometryPolygon, model.GeometryMultiPolygon:
+		polygons, err := g.Polygons()
+		if err != nil {
+			return nil, err
+		}
+
+		result := &geov2.Geometry{Type: string(g.Type), Polygons: make([]*geov2.Polygon, 0, len(polygons))}
+
+		for _, polygon := range polygons {
+			p := &geov2.Polygon{Rings: make([]*geov2.Ring, 0, len(polygon))}
+
+			for _, ring := range polygon {
+				coordinates := make([]float64, 0, 2*len(ring))
+
+				for _, position := range ring {
+					coordinates = append(coordinates, position[0], position[1])
+				}
+
+				p.Rings = append(p.Rings, &geov2.Ring{Coordinates: coordinates})
+			}
+
+			result.Polygons = append(result.Polygons, p)
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("%s geometries are not supported", g.Type)
+}
+
+// geometryFromPB builds the GeoJSON coordinates of a Point, Polygon or MultiPolygon
+func geometryFromPB(g *geov2.Geometry) (model.Geometry, error) {
+	if g == nil {
+		return model.Geometry{}, fmt.Errorf("geometry is required")
+	}
+
+	switch model.GeometryType(g.Type) {
+	case model.GeometryPoint:
+		if len(g.Point) != 2 {
+			return model.Geometry{}, fmt.Errorf("a point needs a longitude and a latitude")
+		}
+
+		return *model.NewPointGeometry([]interface{}{g.Point[0], g.Point[1]}), nil
+	case model.GeometryPolygon:
+		if len(g.Polygons) != 1 {
+			return model.Geometry{}, fmt.Errorf("a polygon needs exactly one polygon")
+		}
+
+		rings, err := ringsFromPB(g.Polygons[0])
+		if err != nil {
+			return model.Geometry{}, err
+		}
+
+		return model.Geometry{Type: model.GeometryPolygon, Coordinates: rings}, nil
+	case model.GeometryMultiPolygon:
+		if len(g.Polygons) == 0 {
+			return model.Geometry{}, fmt.Errorf("a multipolygon needs at least one polygon")
+		}
+
+		polygons := make([]interface{}, 0, len(g.Polygons))
+
+		for _, p := range g.Polygons {
+			rings, err := ringsFromPB(p)
+			if err != nil {
+				return model.Geometry{}, err
+			}
+
+			polygons = append(polygons, rings)
+		}
+
+		return model.Geometry{Type: model.GeometryMultiPolygon, Coordinates: polygons}, nil
+	}
+
+	return model.Geometry{}, fmt.Errorf("geometry must be a Point, Polygon or MultiPolygon")
+}
+
+func ringsFromPB(p *geov2.Polygon) ([]interface{}, error) {
+	if len(p.GetRings()) == 0 {
+		return nil, fmt.Errorf("polygon has no rings")
+	}
+
+	rings := make([]interface{}, 0, len(p.Rings))
+
+	for _, r := range p.Rings {
+		if len(r.Coordinates)%2 != 0 || len(r.Coordinates) < 8 {
+			return nil, fmt.Errorf("polygon rings must have at least 4 longitude, latitude pairs")
+		}
+
+		ring := make([]interface{}, 0, len(r.Coordinates)/2)
+
+		for i := 0; i < len(r.Coordinates); i += 2 {
+			ring = append(ring, []interface{}{r.Coordinates[i], r.Coordinates[i+1]})
+		}
+
+		rings = append(rings, ring)
+	}
+
+	return rings, nil
+}
@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unaryInterceptor reports the call as a New Relic transaction, logs it and turns a panic into an internal error
+func unaryInterceptor(app *newrelic.Application) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		txn := app.StartTransaction(info.FullMethod)
+		start := time.Now()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(info.FullMethod, r)
+			}
+
+			finish(txn, info.FullMethod, start, err)
+		}()
+
+		return handler(newrelic.NewContext(ctx, txn), req)
+	}
+}
+
+// streamInterceptor is the unaryInterceptor of the streams, the transaction lasts until the stream ends
+func streamInterceptor(app *newrelic.Application) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		txn := app.StartTransaction(info.FullMethod)
+		start := time.Now()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(info.FullMethod, r)
+			}
+
+			finish(txn, info.FullMethod, start, err)
+		}()
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: newrelic.NewContext(ss.Context(), txn)})
+	}
+}
+
+// contextStream is a server stream with the context of the transaction
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func recovered(method string, r interface{}) error {
+	log.WithField("method", method).Errorf("gRPC call panicked: %v", r)
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// finish ends the transaction, only the internal errors are noticed since the rest are answers to the client
+func finish(txn *newrelic.Transaction, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	if code == codes.Internal || code == codes.Unknown {
+		txn.NoticeError(err)
+	}
+
+	txn.End()
+
+	log.WithField("method", method).WithField("code", code.String()).WithField("duration", time.Since(start).String()).Info("gRPC call")
+}
@@ -0,0 +1,379 @@
+// Package grpcserver serves the V2 read operations over gRPC, next to the REST routes.
+// The service is defined in proto/geo/v2/geo.proto and its generated code is in pb/geov2.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/pb/geov2"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRecvMsgSize allows request geometries larger than the 4MB default
+const maxRecvMsgSize = 64 * 1024 * 1024
+
+// listBatchSize is how many regions are read at a time by the list stream
+const listBatchSize = 500
+
+// Repository is the part of the repository served over gRPC
+type Repository interface {
+	GetRegionByTypeAndGeoID(regionType model.RegionType, geoID string, r *model.Region) error
+	GetRegions(query repository.QueryRegion, r *[]model.Region) error
+	GetAirportByIATACode(iataCode string, a *model.AirportV2) error
+	GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error
+	GetNearByRegions(q repository.QueryNearby) ([]model.NearbyRegion, error)
+	GetGeoRegion(geoID string, r *model.GeoRegion) error
+	ExportRegions(regionTypes []model.RegionType, polygons bool, fn func(r *model.RegionExport) error) error
+}
+
+// Server implements the GeoService
+type Server struct {
+	geov2.UnimplementedGeoServiceServer
+
+	repo         Repository
+	defaultLimit int
+	maxLimit     int
+}
+
+// NewServer creates a Server, the limits apply to the nearby regions like in the REST routes
+func NewServer(repo Repository, defaultLimit, maxLimit int) *Server {
+	return &Server{repo: repo, defaultLimit: defaultLimit, maxLimit: maxLimit}
+}
+
+// NewGRPCServer registers the service in a gRPC server that reports every call to New Relic, logs it and recovers
+// from its panics
+func NewGRPCServer(s *Server, app *newrelic.Application) *grpc.Server {
+	g := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+		grpc.UnaryInterceptor(unaryInterceptor(app)),
+		grpc.StreamInterceptor(streamInterceptor(app)),
+	)
+
+	geov2.RegisterGeoServiceServer(g, s)
+
+	return g
+}
+
+// Serve listens on the port until the server is stopped or the listener fails
+func Serve(g *grpc.Server, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d. %w", port, err)
+	}
+
+	log.Infof("gRPC listen in port %d", port)
+
+	return g.Serve(lis)
+}
+
+// Shutdown stops accepting calls and waits for the running ones, they are cancelled when the context is done
+func Shutdown(ctx context.Context, g *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		g.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		g.Stop()
+	}
+}
+
+// statusOf maps the repository errors to gRPC status codes
+func statusOf(err error) error {
+	e := pkgErrors.From(err)
+
+	switch e.Status() {
+	case http.StatusNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case http.StatusBadRequest:
+		return status.Error(codes.InvalidArgument, e.Message)
+	}
+
+	log.Error(err)
+
+	return status.Error(codes.Internal, e.Message)
+}
+
+func invalid(format string, args ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, format, args...)
+}
+
+func regionTypes(types []string) ([]model.RegionType, error) {
+	result := make([]model.RegionType, 0, len(types))
+
+	for _, t := range types {
+		rt := model.RegionType(strings.TrimSpace(t))
+
+		if !rt.IsValid() {
+			return nil, invalid("[types] %s is not a valid region type", rt)
+		}
+
+		result = append(result, rt)
+	}
+
+	return result, nil
+}
+
+// GetRegion returns a region by type and id
+func (s *Server) GetRegion(_ context.Context, req *geov2.GetRegionRequest) (*geov2.Region, error) {
+	regionType := model.RegionType(req.Type)
+
+	if !regionType.IsValid() {
+		return nil, invalid("[type] %s is not a valid region type", req.Type)
+	}
+
+	if req.Id == "" {
+		return nil, invalid("[id] is required")
+	}
+
+	var region model.Region
+
+	if err := s.repo.GetRegionByTypeAndGeoID(regionType, req.Id, &region); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return regionToPB(&region), nil
+}
+
+// ListRegions streams the regions of a type that match the filters, ordered by id
+func (s *Server) ListRegions(req *geov2.ListRegionsRequest, stream geov2.GeoService_ListRegionsServer) error {
+	regionType := model.RegionType(req.Type)
+
+	if !regionType.IsValid() {
+		return invalid("[type] %s is not a valid region type", req.Type)
+	}
+
+	if req.Limit < 0 {
+		return invalid("[limit] must be a positive number")
+	}
+
+	q := repository.QueryRegion{
+		RegionType:   regionType,
+		CountryCode:  req.CountryCode,
+		GeoIDs:       req.Ids,
+		AncestorID:   req.AncestorId,
+		AncestorType: model.RegionType(req.AncestorType),
+		Name:         req.Name,
+		After:        req.After,
+	}
+
+	for _, l := range req.NameLanguages {
+		q.NameLanguages = append(q.NameLanguages, model.Language(l))
+	}
+
+	if b := req.Bbox; b != nil {
+		q.BoundingBox = &model.BoundingBox{MinLongitude: b.MinLongitude, MinLatitude: b.MinLatitude, MaxLongitude: b.MaxLongitude, MaxLatitude: b.MaxLatitude}
+	}
+
+	remaining := int(req.Limit)
+
+	for {
+		q.Limit = listBatchSize
+
+		if req.Limit > 0 && remaining < listBatchSize {
+			q.Limit = remaining
+		}
+
+		regions := make([]model.Region, 0, q.Limit)
+
+		if err := s.repo.GetRegions(q, &regions); err != nil {
+			return statusOf(err)
+		}
+
+		for i := range regions {
+			if err := stream.Send(regionToPB(&regions[i])); err != nil {
+				return err
+			}
+		}
+
+		remaining -= len(regions)
+
+		if len(regions) < q.Limit || (req.Limit > 0 && remaining == 0) {
+			return nil
+		}
+
+		q.After = regions[len(regions)-1].GeoID
+	}
+}
+
+// GetAirport returns an airport by IATA code
+func (s *Server) GetAirport(_ context.Context, req *geov2.GetAirportRequest) (*geov2.Airport, error) {
+	if !model.IsIATACode(req.IataCode) {
+		return nil, invalid("[iata_code] %s is not a valid IATA code", req.IataCode)
+	}
+
+	var airport model.AirportV2
+
+	if err := s.repo.GetAirportByIATACode(req.IataCode, &airport); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return airportToPB(&airport), nil
+}
+
+// GetIntersectedRegions returns the id and type of the regions whose polygon intersects the geometry
+func (s *Server) GetIntersectedRegions(_ context.Context, req *geov2.GetIntersectedRegionsRequest) (*geov2.GetIntersectedRegionsResponse, error) {
+	geometry, err := geometryFromPB(req.Geometry)
+	if err != nil {
+		return nil, invalid("[geometry] %s", err)
+	}
+
+	types, err := regionTypes(req.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]model.GeoRegion, 0)
+
+	if err = s.repo.GetIntersectedRegions(geometry, types, &regions); err != nil && !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return nil, statusOf(err)
+	}
+
+	response := &geov2.GetIntersectedRegionsResponse{Regions: make([]*geov2.GeoRegion, 0, len(regions))}
+
+	for _, r := range regions {
+		response.Regions = append(response.Regions, &geov2.GeoRegion{Id: r.GeoID, Type: string(r.Type)})
+	}
+
+	return response, nil
+}
+
+// GetNearbyRegions returns the regions in a radius sorted by distance
+func (s *Server) GetNearbyRegions(_ context.Context, req *geov2.GetNearbyRegionsRequest) (*geov2.GetNearbyRegionsResponse, error) {
+	if req.Latitude < -90 || req.Latitude > 90 {
+		return nil, invalid("[latitude] must be a number between -90 and 90")
+	}
+
+	if req.Longitude < -180 || req.Longitude > 180 {
+		return nil, invalid("[longitude] must be a number between -180 and 180")
+	}
+
+	if req.RadiusKm <= 0 {
+		return nil, invalid("[radius_km] must be a positive number of kilometers")
+	}
+
+	if req.MinDistanceKm < 0 || req.MinDistanceKm >= req.RadiusKm {
+		return nil, invalid("[min_distance_km] must be a number of kilometers lower than the radius")
+	}
+
+	q := repository.QueryNearby{
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Radius:      req.RadiusKm,
+		MinDistance: req.MinDistanceKm,
+		Limit:       s.defaultLimit,
+	}
+
+	if req.Limit != 0 {
+		if req.Limit < 1 || int(req.Limit) > s.maxLimit {
+			return nil, invalid("[limit] must be a number between 1 and %d", s.maxLimit)
+		}
+
+		q.Limit = int(req.Limit)
+	}
+
+	for _, t := range req.Types {
+		rt := model.RegionType(t)
+
+		if !rt.IsValid() && rt != model.RegionTypeAccommodation {
+			return nil, invalid("[types] %s is not a valid region type", rt)
+		}
+
+		q.RegionTypes = append(q.RegionTypes, rt)
+	}
+
+	regions, err := s.repo.GetNearByRegions(q)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	response := &geov2.GetNearbyRegionsResponse{Regions: make([]*geov2.NearbyRegion, 0, len(regions))}
+
+	for i := range regions {
+		region, err := geoRegionToPB(&regions[i].GeoRegion)
+		if err != nil {
+			return nil, statusOf(err)
+		}
+
+		response.Regions = append(response.Regions, &geov2.NearbyRegion{Region: region, DistanceKm: regions[i].DistanceKm})
+	}
+
+	return response, nil
+}
+
+// GetPolygon returns the polygon of a region by id
+func (s *Server) GetPolygon(_ context.Context, req *geov2.GetPolygonRequest) (*geov2.GeoRegion, error) {
+	if req.Id == "" {
+		return nil, invalid("[id] is required")
+	}
+
+	var region model.GeoRegion
+
+	if err := s.repo.GetGeoRegion(req.Id, &region); err != nil {
+		return nil, statusOf(err)
+	}
+
+	result, err := geoRegionToPB(&region)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return result, nil
+}
+
+// ExportRegions streams every region of the types, with its polygon when asked
+func (s *Server) ExportRegions(req *geov2.ExportRegionsRequest, stream geov2.GeoService_ExportRegionsServer) error {
+	if len(req.Types) == 0 {
+		return invalid("[types] is required")
+	}
+
+	types, err := regionTypes(req.Types)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.ExportRegions(types, req.Polygons, func(r *model.RegionExport) error {
+		exported := &geov2.ExportedRegion{Region: regionToPB(&r.Region)}
+
+		for i := range r.Polygons {
+			if p := &r.Polygons[i]; p.Type == r.Type && p.Geometry.Type != "" {
+				geometry, err := geometryToPB(p.Geometry)
+				if err != nil {
+					return fmt.Errorf("invalid geometry of region %s. %w", r.GeoID, err)
+				}
+
+				exported.Geometry = geometry
+
+				break
+			}
+		}
+
+		return stream.Send(exported)
+	})
+
+	if err != nil {
+		if stream.Context().Err() != nil {
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+
+		return statusOf(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/pb/geov2"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeRepository struct {
+	regions []model.Region
+	queries []repository.QueryRegion
+	panics  bool
+}
+
+func (f *fakeRepository) GetRegionByTypeAndGeoID(regionType model.RegionType, geoID string, r *model.Region) error {
+	if f.panics {
+		panic("nil map")
+	}
+
+	for _, region := range f.regions {
+		if region.Type == regionType && region.GeoID == geoID {
+			*r = region
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("region %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+}
+
+func (f *fakeRepository) GetRegions(query repository.QueryRegion, r *[]model.Region) error {
+	f.queries = append(f.queries, query)
+
+	for _, region := range f.regions {
+		if region.Type == query.RegionType && region.GeoID > query.After && len(*r) < query.Limit {
+			*r = append(*r, region)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeRepository) GetAirportByIATACode(iataCode string, a *model.AirportV2) error {
+	return fmt.Errorf("airport %s not found. %w", iataCode, pkgErrors.ErrEntityNotFound)
+}
+
+func (f *fakeRepository) GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error {
+	*r = append(*r, model.GeoRegion{BaseRegion: model.BaseRegion{GeoID: "2", Type: model.RegionTypeCity}})
+
+	return nil
+}
+
+func (f *fakeRepository) GetNearByRegions(q repository.QueryNearby) ([]model.NearbyRegion, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func (f *fakeRepository) GetGeoRegion(geoID string, r *model.GeoRegion) error {
+	return fmt.Errorf("region %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+}
+
+func (f *fakeRepository) ExportRegions(regionTypes []model.RegionType, polygons bool, fn func(r *model.RegionExport) error) error {
+	if f.panics {
+		panic("nil map")
+	}
+
+	for _, region := range f.regions {
+		if err := fn(&model.RegionExport{Region: region}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func cities(n int) []model.Region {
+	regions := make([]model.Region, 0, n)
+
+	for i := 0; i < n; i++ {
+		regions = append(regions, model.Region{
+			BaseRegion: model.BaseRegion{GeoID: fmt.Sprintf("%04d", i), Type: model.RegionTypeCity},
+			Name:       map[model.Language]string{"es": fmt.Sprintf("Ciudad %d", i)},
+		})
+	}
+
+	return regions
+}
+
+func dial(t *testing.T, repo Repository) geov2.GeoServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+
+	g := NewGRPCServer(NewServer(repo, 100, 1000), nil)
+
+	go func() { _ = g.Serve(lis) }()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		g.Stop()
+	})
+
+	return geov2.NewGeoServiceClient(conn)
+}
+
+func TestGetRegion(t *testing.T) {
+	client := dial(t, &fakeRepository{regions: cities(3)})
+
+	region, err := client.GetRegion(context.Background(), &geov2.GetRegionRequest{Type: "city", Id: "0001"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "0001", region.Id)
+	assert.Equal(t, "Ciudad 1", region.Name["es"])
+
+	_, err = client.GetRegion(context.Background(), &geov2.GetRegionRequest{Type: "city", Id: "9999"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetRegion(context.Background(), &geov2.GetRegionRequest{Type: "galaxy", Id: "1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListRegionsPages(t *testing.T) {
+	repo := &fakeRepository{regions: cities(1200)}
+	client := dial(t, repo)
+
+	stream, err := client.ListRegions(context.Background(), &geov2.ListRegionsRequest{Type: "city"})
+	require.NoError(t, err)
+
+	ids := make([]string, 0)
+
+	for {
+		region, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		ids = append(ids, region.Id)
+	}
+
+	assert.Len(t, ids, 1200)
+	assert.Equal(t, "1199", ids[1199])
+	require.Len(t, repo.queries, 3)
+	assert.Equal(t, "0499", repo.queries[1].After)
+}
+
+func TestListRegionsLimit(t *testing.T) {
+	repo := &fakeRepository{regions: cities(1200)}
+	client := dial(t, repo)
+
+	stream, err := client.ListRegions(context.Background(), &geov2.ListRegionsRequest{Type: "city", Limit: 600})
+	require.NoError(t, err)
+
+	count := 0
+
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, 600, count)
+	require.Len(t, repo.queries, 2)
+	assert.Equal(t, 100, repo.queries[1].Limit)
+}
+
+func TestGetNearbyRegionsInternalError(t *testing.T) {
+	client := dial(t, &fakeRepository{})
+
+	_, err := client.GetNearbyRegions(context.Background(), &geov2.GetNearbyRegionsRequest{Latitude: -34.6, Longitude: -58.4, RadiusKm: 10})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.GetNearbyRegions(context.Background(), &geov2.GetNearbyRegionsRequest{Latitude: -34.6, Longitude: -58.4, RadiusKm: 10, Limit: 5000})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRecoversFromPanics(t *testing.T) {
+	// Given
+	repo := &fakeRepository{regions: cities(3), panics: true}
+	client := dial(t, repo)
+
+	// When
+	_, err := client.GetRegion(context.Background(), &geov2.GetRegionRequest{Type: "city", Id: "0001"})
+
+	// Then
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	stream, err := client.ExportRegions(context.Background(), &geov2.ExportRegionsRequest{Types: []string{"city"}})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	repo.panics = false
+
+	region, err := client.GetRegion(context.Background(), &geov2.GetRegionRequest{Type: "city", Id: "0001"})
+	require.NoError(t, err)
+	assert.Equal(t, "0001", region.Id)
+}
+
+func TestGetIntersectedRegions(t *testing.T) {
+	client := dial(t, &fakeRepository{})
+
+	geometry := &geov2.Geometry{Type: "Polygon", Polygons: []*geov2.Polygon{{Rings: []*geov2.Ring{
+		{Coordinates: []float64{-58.5, -34.7, -58.3, -34.7, -58.3, -34.5, -58.5, -34.7}},
+	}}}}
+
+	response, err := client.GetIntersectedRegions(context.Background(), &geov2.GetIntersectedRegionsRequest{Geometry: geometry})
+
+	require.NoError(t, err)
+	require.Len(t, response.Regions, 1)
+	assert.Equal(t, "2", response.Regions[0].Id)
+
+	geometry.Polygons[0].Rings[0].Coordinates = []float64{-58.5, -34.7}
+
+	_, err = client.GetIntersectedRegions(context.Background(), &geov2.GetIntersectedRegionsRequest{Geometry: geometry})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGeometryRoundTrip(t *testing.T) {
+	coordinates := []interface{}{
+		[]interface{}{[]interface{}{
+			[]interface{}{-64.3, -31.5},
+			[]interface{}{-64.1, -31.5},
+			[]interface{}{-64.1, -31.3},
+			[]interface{}{-64.3, -31.5},
+		}},
+	}
+
+	pb, err := geometryToPB(model.Geometry{Type: model.GeometryMultiPolygon, Coordinates: coordinates})
+	require.NoError(t, err)
+	require.Len(t, pb.Polygons, 1)
+	assert.Equal(t, []float64{-64.3, -31.5, -64.1, -31.5, -64.1, -31.3, -64.3, -31.5}, pb.Polygons[0].Rings[0].Coordinates)
+
+	g, err := geometryFromPB(pb)
+	require.NoError(t, err)
+	assert.Equal(t, model.GeometryMultiPolygon, g.Type)
+	assert.Equal(t, coordinates, g.Coordinates)
+
+	empty, err := geometryToPB(model.Geometry{})
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+}
@@ -0,0 +1,283 @@
+// Package importer loads external datasets into the geo collections and reports what changed
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+)
+
+// AirportStore is the part of the repository used by the airport importer
+type AirportStore interface {
+	GetAirportByIATACode(iataCode string, a *model.AirportV2) error
+	SaveAirport(a *model.AirportV2) error
+	UpdateAirport(a *model.AirportV2) error
+	GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error
+	GetRegionByTypeAndGeoID(regionType model.RegionType, geoID string, r *model.Region) error
+}
+
+// RowError is a CSV row that was not imported
+type RowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// AirportsSummary is the diff between the CSV and the stored airports, the changed airports list the fields that changed
+type AirportsSummary struct {
+	Created   []string            `json:"created"`
+	Changed   map[string][]string `json:"changed"`
+	Unchanged int                 `json:"unchanged"`
+	Skipped   int                 `json:"skipped"`
+	Invalid   []RowError          `json:"invalid"`
+}
+
+// AirportImporter upserts airports by IATA code from an OurAirports airports.csv.
+// Rows without IATA code and closed airports are skipped. With DryRun nothing is written.
+type AirportImporter struct {
+	store  AirportStore
+	DryRun bool
+}
+
+// NewAirportImporter creates an AirportImporter
+func NewAirportImporter(store AirportStore, dryRun bool) *AirportImporter {
+	return &AirportImporter{store: store, DryRun: dryRun}
+}
+
+// coordinatesTolerance is how much the coordinates can move, in degrees, before the airport is considered changed
+const coordinatesTolerance = 1e-6
+
+// Import reads the CSV row by row, the header names the columns so their order does not matter
+func (i *AirportImporter) Import(r io.Reader) (*AirportsSummary, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header. %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for idx, name := range header {
+		columns[strings.TrimSpace(name)] = idx
+	}
+
+	for _, required := range []string{"iata_code", "name", "latitude_deg", "longitude_deg", "iso_country"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv column %s not found", required)
+		}
+	}
+
+	summary := &AirportsSummary{Created: []string{}, Changed: map[string][]string{}, Invalid: []RowError{}}
+	seen := map[string]int{}
+	line := 1
+
+	for {
+		record, err := reader.Read()
+		line++
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return summary, fmt.Errorf("failed to read csv line %d. %w", line, err)
+		}
+
+		get := func(column string) string {
+			if idx, ok := columns[column]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+
+			return ""
+		}
+
+		if get("iata_code") == "" || get("type") == "closed" {
+			summary.Skipped++
+
+			continue
+		}
+
+		airport, rowErr := parseAirport(get)
+		if rowErr != nil {
+			rowErr.Line = line
+			summary.Invalid = append(summary.Invalid, *rowErr)
+
+			continue
+		}
+
+		if first, ok := seen[airport.IataCode]; ok {
+			summary.Invalid = append(summary.Invalid, RowError{
+				Line: line, Field: "iata_code", Message: fmt.Sprintf("%s is repeated, first seen at line %d", airport.IataCode, first),
+			})
+
+			continue
+		}
+
+		seen[airport.IataCode] = line
+
+		if err = i.upsert(airport, summary); err != nil {
+			return summary, err
+		}
+	}
+
+	sort.Strings(summary.Created)
+
+	return summary, nil
+}
+
+func parseAirport(get func(column string) string) (*model.AirportV2, *RowError) {
+	a := model.AirportV2{
+		IataCode:    strings.ToUpper(get("iata_code")),
+		CountryCode: strings.ToUpper(get("iso_country")),
+	}
+
+	if !model.IsIATACode(a.IataCode) {
+		return nil, &RowError{Field: "iata_code", Message: fmt.Sprintf("%s is not a valid IATA code", a.IataCode)}
+	}
+
+	if icao := strings.ToUpper(get("icao_code")); icao != "" {
+		if !model.IsICAOCode(icao) {
+			return nil, &RowError{Field: "icao_code", Message: fmt.Sprintf("%s is not a valid ICAO code", icao)}
+		}
+
+		a.IcaoCode = icao
+	} else if gps := strings.ToUpper(get("gps_code")); model.IsICAOCode(gps) {
+		// Older files have no icao_code column, the gps code is the ICAO one when it has its shape
+		a.IcaoCode = gps
+	}
+
+	if !model.IsISOCountryCode(a.CountryCode) {
+		return nil, &RowError{Field: "iso_country", Message: fmt.Sprintf("%s is not a valid country code", a.CountryCode)}
+	}
+
+	name := get("name")
+	if name == "" {
+		return nil, &RowError{Field: "name", Message: "name is required"}
+	}
+
+	a.Name = map[model.Language]string{"en": name}
+
+	lat, errLat := strconv.ParseFloat(get("latitude_deg"), 64)
+	lon, errLon := strconv.ParseFloat(get("longitude_deg"), 64)
+
+	if errLat != nil || errLon != nil || !model.IsValidCoordinate(lat, lon) {
+		return nil, &RowError{Field: "coordinates", Message: fmt.Sprintf("%s,%s are not valid coordinates", get("latitude_deg"), get("longitude_deg"))}
+	}
+
+	a.Coordinates = model.Coordinates{Latitude: lat, Longitude: lon}
+
+	return &a, nil
+}
+
+// upsert merges the imported fields into the stored airport, the names in other languages are kept
+func (i *AirportImporter) upsert(imported *model.AirportV2, summary *AirportsSummary) error {
+	region, err := i.cityOf(imported.Coordinates)
+	if err != nil {
+		return err
+	}
+
+	var stored model.AirportV2
+
+	err = i.store.GetAirportByIATACode(imported.IataCode, &stored)
+
+	if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		if region != nil {
+			imported.Region = *region
+		}
+
+		summary.Created = append(summary.Created, imported.IataCode)
+
+		if i.DryRun {
+			return nil
+		}
+
+		return i.store.SaveAirport(imported)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	changed := make([]string, 0)
+
+	if stored.Name["en"] != imported.Name["en"] {
+		if stored.Name == nil {
+			stored.Name = map[model.Language]string{}
+		}
+
+		stored.Name["en"] = imported.Name["en"]
+		changed = append(changed, "name")
+	}
+
+	if imported.IcaoCode != "" && stored.IcaoCode != imported.IcaoCode {
+		stored.IcaoCode = imported.IcaoCode
+		changed = append(changed, "icao_code")
+	}
+
+	if stored.CountryCode != imported.CountryCode {
+		stored.CountryCode = imported.CountryCode
+		changed = append(changed, "country_code")
+	}
+
+	if math.Abs(stored.Coordinates.Latitude-imported.Coordinates.Latitude) > coordinatesTolerance ||
+		math.Abs(stored.Coordinates.Longitude-imported.Coordinates.Longitude) > coordinatesTolerance {
+		stored.Coordinates = imported.Coordinates
+		changed = append(changed, "coordinates")
+	}
+
+	if region != nil && stored.Region.ID != region.ID {
+		stored.Region = *region
+		changed = append(changed, "region")
+	}
+
+	if len(changed) == 0 {
+		summary.Unchanged++
+
+		return nil
+	}
+
+	summary.Changed[imported.IataCode] = changed
+
+	if i.DryRun {
+		return nil
+	}
+
+	return i.store.UpdateAirport(&stored)
+}
+
+// cityOf returns the city whose polygon contains the coordinates, nil when there is none
+func (i *AirportImporter) cityOf(c model.Coordinates) (*model.AirportRegion, error) {
+	regions := make([]model.GeoRegion, 0)
+	point := model.NewPointGeometry([]interface{}{c.Longitude, c.Latitude})
+
+	err := i.store.GetIntersectedRegions(*point, []model.RegionType{model.RegionTypeCity}, &regions)
+	if errors.Is(err, pkgErrors.ErrEntityNotFound) || (err == nil && len(regions) == 0) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var city model.Region
+
+	err = i.store.GetRegionByTypeAndGeoID(model.RegionTypeCity, regions[0].GeoID, &city)
+	if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AirportRegion{ID: city.GeoID, Type: string(model.RegionTypeCity), Name: city.Name}, nil
+}
@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAirportStore struct {
+	airports map[string]model.AirportV2
+	city     *model.Region
+	saved    []string
+	updated  []string
+}
+
+func (f *fakeAirportStore) GetAirportByIATACode(iataCode string, a *model.AirportV2) error {
+	stored, ok := f.airports[iataCode]
+	if !ok {
+		return pkgErrors.ErrEntityNotFound
+	}
+
+	*a = stored
+
+	return nil
+}
+
+func (f *fakeAirportStore) SaveAirport(a *model.AirportV2) error {
+	f.saved = append(f.saved, a.IataCode)
+	f.airports[a.IataCode] = *a
+
+	return nil
+}
+
+func (f *fakeAirportStore) UpdateAirport(a *model.AirportV2) error {
+	f.updated = append(f.updated, a.IataCode)
+	f.airports[a.IataCode] = *a
+
+	return nil
+}
+
+func (f *fakeAirportStore) GetIntersectedRegions(_ model.Geometry, _ []model.RegionType, r *[]model.GeoRegion) error {
+	if f.city != nil {
+		*r = append(*r, model.GeoRegion{BaseRegion: model.BaseRegion{GeoID: f.city.GeoID}})
+	}
+
+	return nil
+}
+
+func (f *fakeAirportStore) GetRegionByTypeAndGeoID(_ model.RegionType, _ string, r *model.Region) error {
+	*r = *f.city
+
+	return nil
+}
+
+const airportsCSV = `id,ident,type,name,latitude_deg,longitude_deg,iso_country,gps_code,iata_code
+1,SAEZ,large_airport,Ministro Pistarini International Airport,-34.8222,-58.5358,AR,SAEZ,EZE
+2,SABE,medium_airport,Jorge Newbery Airpark,-34.5592,-58.4156,AR,SABE,AEP
+3,XXXX,closed,Old Airport,-34.1,-58.1,AR,,OLD
+4,SA01,small_airport,No Code,-34.2,-58.2,AR,,
+5,SAZZ,small_airport,Bad Code,-34.3,-58.3,AR,,E1E
+6,SAYY,small_airport,Bad Coordinates,0,0,AR,,BCO
+7,SAEZ,large_airport,Repeated,-34.8222,-58.5358,AR,SAEZ,EZE
+`
+
+func newFakeStore() *fakeAirportStore {
+	return &fakeAirportStore{
+		airports: map[string]model.AirportV2{
+			"AEP": {
+				IataCode:    "AEP",
+				IcaoCode:    "SABE",
+				Name:        map[model.Language]string{"en": "Jorge Newbery Airpark", "es": "Aeroparque Jorge Newbery"},
+				CountryCode: "AR",
+				Coordinates: model.Coordinates{Latitude: -34.5592, Longitude: -58.4156},
+				Region:      model.AirportRegion{ID: "6139184", Type: "city"},
+			},
+		},
+		city: &model.Region{BaseRegion: model.BaseRegion{GeoID: "6139184"}, Name: map[model.Language]string{"en": "Buenos Aires"}},
+	}
+}
+
+func TestAirportImporter(t *testing.T) {
+	// Given
+	store := newFakeStore()
+	importer := NewAirportImporter(store, false)
+
+	// When
+	summary, err := importer.Import(strings.NewReader(airportsCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"EZE"}, summary.Created)
+	assert.Empty(t, summary.Changed)
+	assert.Equal(t, 1, summary.Unchanged)
+	assert.Equal(t, 2, summary.Skipped)
+	require.Len(t, summary.Invalid, 3)
+	assert.Equal(t, RowError{Line: 6, Field: "iata_code", Message: "E1E is not a valid IATA code"}, summary.Invalid[0])
+	assert.Equal(t, "coordinates", summary.Invalid[1].Field)
+	assert.Equal(t, 8, summary.Invalid[2].Line)
+
+	assert.Equal(t, []string{"EZE"}, store.saved)
+	assert.Empty(t, store.updated)
+
+	eze := store.airports["EZE"]
+	assert.Equal(t, "SAEZ", eze.IcaoCode)
+	assert.Equal(t, "6139184", eze.Region.ID)
+	assert.Equal(t, "city", eze.Region.Type)
+}
+
+func TestAirportImporterChanges(t *testing.T) {
+	// Given
+	store := newFakeStore()
+	importer := NewAirportImporter(store, false)
+	csv := "iata_code,icao_code,name,latitude_deg,longitude_deg,iso_country\n" +
+		"AEP,SABE,Aeroparque,-34.5600,-58.4156,AR\n"
+
+	// When
+	summary, err := importer.Import(strings.NewReader(csv))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"AEP": {"name", "coordinates"}}, summary.Changed)
+	assert.Equal(t, []string{"AEP"}, store.updated)
+	assert.Equal(t, "Aeroparque", store.airports["AEP"].Name["en"])
+	assert.Equal(t, "Aeroparque Jorge Newbery", store.airports["AEP"].Name["es"])
+}
+
+func TestAirportImporterCountryCode(t *testing.T) {
+	// Given
+	store := newFakeStore()
+	importer := NewAirportImporter(store, false)
+	csv := "iata_code,icao_code,name,latitude_deg,longitude_deg,iso_country\n" +
+		"XXA,,Unassigned,-34.5600,-58.4156,ZZ\n"
+
+	// When
+	summary, err := importer.Import(strings.NewReader(csv))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, summary.Invalid, 1)
+	assert.Equal(t, "iso_country", summary.Invalid[0].Field)
+	assert.Empty(t, store.saved)
+}
+
+func TestAirportImporterDryRun(t *testing.T) {
+	// Given
+	store := newFakeStore()
+	importer := NewAirportImporter(store, true)
+
+	// When
+	summary, err := importer.Import(strings.NewReader(airportsCSV))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"EZE"}, summary.Created)
+	assert.Empty(t, store.saved)
+	assert.Empty(t, store.updated)
+}
+
+func TestAirportImporterMissingColumn(t *testing.T) {
+	_, err := NewAirportImporter(newFakeStore(), false).Import(strings.NewReader("iata_code,name\nEZE,Ezeiza\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,459 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+)
+
+// RegionStore is the part of the repository used by the region importer
+type RegionStore interface {
+	GetRegionByTypeAndGeoID(regionType model.RegionType, geoID string, r *model.Region) error
+	SaveRegion(r *model.Region) error
+	UpdateRegion(r *model.Region) error
+	DeleteRegion(regionType model.RegionType, geoID string) error
+	GetRegionGeoIDs(regionType model.RegionType) ([]string, error)
+	GetGeoRegion(geoID string, r *model.GeoRegion) error
+	SaveGeoRegion(r *model.GeoRegion) error
+	UpdateGeoRegion(r *model.GeoRegion) error
+}
+
+// ProviderRegion is a line of the provider region dump
+type ProviderRegion struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	CountryCode string `json:"country_code"`
+	Coordinates struct {
+		CenterLongitude float64         `json:"center_longitude"`
+		CenterLatitude  float64         `json:"center_latitude"`
+		BoundingPolygon *model.Geometry `json:"bounding_polygon"`
+	} `json:"coordinates"`
+	Ancestors []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"ancestors"`
+	Descendants map[string][]string `json:"descendants"`
+	PropertyIDs []string            `json:"property_ids"`
+}
+
+// providerRegionTypes maps the provider types to the region types, the ones missing, like airports, are not imported
+var providerRegionTypes = map[string]model.RegionType{
+	"continent":           model.RegionTypeContinent,
+	"country":             model.RegionTypeCountry,
+	"province_state":      model.RegionTypeProvinceState,
+	"high_level_region":   model.RegionTypeHighLevelRegion,
+	"multi_city_vicinity": model.RegionTypeMultiCityVicinity,
+	"city":                model.RegionTypeCity,
+	"neighborhood":        model.RegionTypeNeighborhood,
+	"point_of_interest":   model.RegionTypePOI,
+	"train_station":       model.RegionTypeTrainStation,
+	"metro_station":       model.RegionTypeMetroStation,
+}
+
+// RegionRef identifies an imported region, fields lists what changed
+type RegionRef struct {
+	ID     string           `json:"id"`
+	Type   model.RegionType `json:"type"`
+	Fields []string         `json:"fields,omitempty"`
+}
+
+// RegionsSummary is the diff between the dump and the stored regions
+type RegionsSummary struct {
+	Added     []RegionRef `json:"added"`
+	Changed   []RegionRef `json:"changed"`
+	Removed   []RegionRef `json:"removed"`
+	Unchanged int         `json:"unchanged"`
+	Skipped   int         `json:"skipped"`
+	Invalid   []RowError  `json:"invalid"`
+}
+
+// RegionImporter upserts the regions of a provider JSONL dump, one region per line.
+// Only what changed is written, so a dump can be imported again to refresh the catalog.
+// The name of the dump is stored in Language, names in other languages are kept.
+// The stored regions of the imported types missing from the dump are reported as removed, and deleted with Prune
+// when every line of the dump is valid.
+// With DryRun nothing is written.
+type RegionImporter struct {
+	store    RegionStore
+	Language model.Language
+	Prune    bool
+	DryRun   bool
+}
+
+// NewRegionImporter creates a RegionImporter
+func NewRegionImporter(store RegionStore, language model.Language, prune, dryRun bool) *RegionImporter {
+	return &RegionImporter{store: store, Language: language, Prune: prune, DryRun: dryRun}
+}
+
+// maxRegionLineSize is the longest line accepted, country polygons are large
+const maxRegionLineSize = 64 * 1024 * 1024
+
+// Import reads the dump line by line
+func (i *RegionImporter) Import(r io.Reader) (*RegionsSummary, error) {
+	summary := &RegionsSummary{Added: []RegionRef{}, Changed: []RegionRef{}, Removed: []RegionRef{}, Invalid: []RowError{}}
+	seen := map[model.RegionType]map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRegionLineSize)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		var p ProviderRegion
+
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			summary.Invalid = append(summary.Invalid, RowError{Line: line, Message: fmt.Sprintf("invalid json. %s", err)})
+
+			continue
+		}
+
+		regionType, ok := providerRegionTypes[p.Type]
+		if !ok {
+			summary.Skipped++
+
+			continue
+		}
+
+		// The region is seen before it is validated, an invalid line must not remove the stored region
+		if p.ID != "" {
+			if seen[regionType] == nil {
+				seen[regionType] = map[string]bool{}
+			}
+
+			if seen[regionType][p.ID] {
+				summary.Invalid = append(summary.Invalid, RowError{
+					Line: line, Field: "id", Message: fmt.Sprintf("%s %s is repeated", regionType, p.ID),
+				})
+
+				continue
+			}
+
+			seen[regionType][p.ID] = true
+		}
+
+		region, geoRegion, rowErr := i.parseRegion(&p, regionType)
+		if rowErr != nil {
+			rowErr.Line = line
+			summary.Invalid = append(summary.Invalid, *rowErr)
+
+			continue
+		}
+
+		if err := i.upsert(region, geoRegion, summary); err != nil {
+			return summary, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return summary, fmt.Errorf("failed to read line %d. %w", line+1, err)
+	}
+
+	if err := i.remove(seen, summary); err != nil {
+		return summary, err
+	}
+
+	return summary, nil
+}
+
+func (i *RegionImporter) parseRegion(p *ProviderRegion, regionType model.RegionType) (*model.Region, *model.GeoRegion, *RowError) {
+	if p.ID == "" {
+		return nil, nil, &RowError{Field: "id", Message: "id is required"}
+	}
+
+	if p.Name == "" {
+		return nil, nil, &RowError{Field: "name", Message: "name is required"}
+	}
+
+	countryCode := strings.ToUpper(p.CountryCode)
+	if countryCode != "" && !model.IsISOCountryCode(countryCode) {
+		return nil, nil, &RowError{Field: "country_code", Message: fmt.Sprintf("%s is not a valid country code", p.CountryCode)}
+	}
+
+	c := p.Coordinates
+	if !model.IsValidCoordinate(c.CenterLatitude, c.CenterLongitude) {
+		return nil, nil, &RowError{Field: "coordinates", Message: fmt.Sprintf("%v,%v are not valid coordinates", c.CenterLatitude, c.CenterLongitude)}
+	}
+
+	region := &model.Region{
+		BaseRegion:  model.BaseRegion{GeoID: p.ID, Type: regionType},
+		Name:        map[model.Language]string{i.Language: p.Name},
+		CountryCode: countryCode,
+		Center:      model.Center{Latitude: c.CenterLatitude, Longitude: c.CenterLongitude},
+		Ancestors:   make([]model.Ancestor, 0, len(p.Ancestors)),
+		Descendants: providerDescendants(p),
+	}
+
+	for _, a := range p.Ancestors {
+		if t, ok := providerRegionTypes[a.Type]; ok {
+			region.Ancestors = append(region.Ancestors, model.Ancestor{ID: a.ID, Type: t})
+		}
+	}
+
+	if c.BoundingPolygon == nil {
+		return region, nil, nil
+	}
+
+	if _, err := c.BoundingPolygon.Polygons(); err != nil {
+		return nil, nil, &RowError{Field: "bounding_polygon", Message: err.Error()}
+	}
+
+	geoRegion := &model.GeoRegion{BaseRegion: region.BaseRegion, Geometry: *c.BoundingPolygon}
+
+	return region, geoRegion, nil
+}
+
+// providerDescendants maps the descendants by type, the lists are sorted so they can be compared
+func providerDescendants(p *ProviderRegion) model.Descendants {
+	ids := func(ids []string) []string {
+		if len(ids) == 0 {
+			return nil
+		}
+
+		sorted := append([]string(nil), ids...)
+		sort.Strings(sorted)
+
+		return sorted
+	}
+
+	d := p.Descendants
+
+	return model.Descendants{
+		Cities:              ids(d["city"]),
+		Countries:           ids(d["country"]),
+		POIs:                ids(d["point_of_interest"]),
+		HighLevelRegions:    ids(d["high_level_region"]),
+		TrainStations:       ids(d["train_station"]),
+		MetroStations:       ids(d["metro_station"]),
+		Neighbourhoods:      ids(d["neighborhood"]),
+		MultiCityVicinities: ids(d["multi_city_vicinity"]),
+		ProvinceStates:      ids(d["province_state"]),
+		Accommodations:      ids(p.PropertyIDs),
+	}
+}
+
+func (i *RegionImporter) upsert(imported *model.Region, geoRegion *model.GeoRegion, summary *RegionsSummary) error {
+	var stored model.Region
+
+	err := i.store.GetRegionByTypeAndGeoID(imported.Type, imported.GeoID, &stored)
+
+	if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		summary.Added = append(summary.Added, RegionRef{ID: imported.GeoID, Type: imported.Type})
+
+		if i.DryRun {
+			return nil
+		}
+
+		if err = i.store.SaveRegion(imported); err != nil {
+			return err
+		}
+
+		if geoRegion != nil {
+			return i.store.SaveGeoRegion(geoRegion)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	changed := diffRegion(&stored, imported, i.Language)
+	regionChanged := len(changed) > 0
+
+	var storedPolygon *model.GeoRegion
+
+	polygonChanged := false
+
+	if geoRegion != nil {
+		// A dump without polygon keeps the stored one
+		if storedPolygon, err = i.storedPolygon(geoRegion.GeoID); err != nil {
+			return err
+		}
+
+		polygonChanged = storedPolygon == nil || storedPolygon.Geometry.Type != geoRegion.Geometry.Type ||
+			!reflect.DeepEqual(storedPolygon.Geometry.Coordinates, geoRegion.Geometry.Coordinates)
+	}
+
+	if polygonChanged {
+		changed = append(changed, "bounding_polygon")
+	}
+
+	if len(changed) == 0 {
+		summary.Unchanged++
+
+		return nil
+	}
+
+	summary.Changed = append(summary.Changed, RegionRef{ID: imported.GeoID, Type: imported.Type, Fields: changed})
+
+	if i.DryRun {
+		return nil
+	}
+
+	if regionChanged {
+		if err = i.store.UpdateRegion(&stored); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case !polygonChanged:
+		return nil
+	case storedPolygon == nil:
+		return i.store.SaveGeoRegion(geoRegion)
+	default:
+		geoRegion.ID = storedPolygon.ID
+
+		return i.store.UpdateGeoRegion(geoRegion)
+	}
+}
+
+// diffRegion merges the imported fields into the stored region and returns the names of the ones that changed.
+// Aliases and the names in other languages are kept.
+func diffRegion(stored, imported *model.Region, language model.Language) []string {
+	changed := make([]string, 0)
+
+	if stored.Name[language] != imported.Name[language] {
+		if stored.Name == nil {
+			stored.Name = map[model.Language]string{}
+		}
+
+		stored.Name[language] = imported.Name[language]
+		changed = append(changed, "name")
+	}
+
+	if stored.CountryCode != imported.CountryCode {
+		stored.CountryCode = imported.CountryCode
+		changed = append(changed, "country_code")
+	}
+
+	if math.Abs(stored.Center.Latitude-imported.Center.Latitude) > coordinatesTolerance ||
+		math.Abs(stored.Center.Longitude-imported.Center.Longitude) > coordinatesTolerance {
+		stored.Center = imported.Center
+		changed = append(changed, "center")
+	}
+
+	if !sameAncestors(stored.Ancestors, imported.Ancestors) {
+		stored.Ancestors = imported.Ancestors
+		changed = append(changed, "ancestors")
+	}
+
+	if !reflect.DeepEqual(sortedDescendants(stored.Descendants), imported.Descendants) {
+		stored.Descendants = imported.Descendants
+		changed = append(changed, "descendants")
+	}
+
+	return changed
+}
+
+func sameAncestors(a, b []model.Ancestor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedDescendants(d model.Descendants) model.Descendants {
+	p := ProviderRegion{
+		Descendants: map[string][]string{
+			"city":                d.Cities,
+			"country":             d.Countries,
+			"point_of_interest":   d.POIs,
+			"high_level_region":   d.HighLevelRegions,
+			"train_station":       d.TrainStations,
+			"metro_station":       d.MetroStations,
+			"neighborhood":        d.Neighbourhoods,
+			"multi_city_vicinity": d.MultiCityVicinities,
+			"province_state":      d.ProvinceStates,
+		},
+		PropertyIDs: d.Accommodations,
+	}
+
+	return providerDescendants(&p)
+}
+
+// storedPolygon returns the stored polygon of a region, nil when there is none
+func (i *RegionImporter) storedPolygon(geoID string) (*model.GeoRegion, error) {
+	var stored model.GeoRegion
+
+	err := i.store.GetGeoRegion(geoID, &stored)
+	if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// remove finds the stored regions of the imported types that are not in the dump
+// remove reports the stored regions missing from the dump and deletes them with Prune. Nothing is deleted when
+// the dump has invalid lines, a line that could not be read may be one of the regions.
+func (i *RegionImporter) remove(seen map[model.RegionType]map[string]bool, summary *RegionsSummary) error {
+	prune := i.Prune && !i.DryRun && len(summary.Invalid) == 0
+
+	types := make([]model.RegionType, 0, len(seen))
+	for t := range seen {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(a, b int) bool { return types[a] < types[b] })
+
+	for _, t := range types {
+		ids, err := i.store.GetRegionGeoIDs(t)
+		if err != nil {
+			return err
+		}
+
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			if seen[t][id] {
+				continue
+			}
+
+			summary.Removed = append(summary.Removed, RegionRef{ID: id, Type: t})
+
+			if !prune {
+				continue
+			}
+
+			if err = i.store.DeleteRegion(t, id); err != nil && !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+				return err
+			}
+		}
+	}
+
+	if i.Prune && !i.DryRun && !prune && len(summary.Removed) > 0 {
+		return fmt.Errorf("%d removed regions were not deleted, the dump has %d invalid lines", len(summary.Removed), len(summary.Invalid))
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRegionStore struct {
+	regions  map[string]model.Region
+	polygons map[string]model.GeoRegion
+	deleted  []string
+	writes   int
+}
+
+func newFakeRegionStore() *fakeRegionStore {
+	return &fakeRegionStore{regions: map[string]model.Region{}, polygons: map[string]model.GeoRegion{}}
+}
+
+func regionKey(t model.RegionType, geoID string) string {
+	return fmt.Sprintf("%s/%s", t, geoID)
+}
+
+func (f *fakeRegionStore) GetRegionByTypeAndGeoID(t model.RegionType, geoID string, r *model.Region) error {
+	stored, ok := f.regions[regionKey(t, geoID)]
+	if !ok {
+		return pkgErrors.ErrEntityNotFound
+	}
+
+	*r = stored
+
+	return nil
+}
+
+func (f *fakeRegionStore) SaveRegion(r *model.Region) error {
+	f.writes++
+	f.regions[regionKey(r.Type, r.GeoID)] = *r
+
+	return nil
+}
+
+func (f *fakeRegionStore) UpdateRegion(r *model.Region) error {
+	return f.SaveRegion(r)
+}
+
+func (f *fakeRegionStore) DeleteRegion(t model.RegionType, geoID string) error {
+	f.deleted = append(f.deleted, geoID)
+	delete(f.regions, regionKey(t, geoID))
+
+	return nil
+}
+
+func (f *fakeRegionStore) GetRegionGeoIDs(t model.RegionType) ([]string, error) {
+	ids := make([]string, 0)
+
+	for _, r := range f.regions {
+		if r.Type == t {
+			ids = append(ids, r.GeoID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (f *fakeRegionStore) GetGeoRegion(geoID string, r *model.GeoRegion) error {
+	stored, ok := f.polygons[geoID]
+	if !ok {
+		return pkgErrors.ErrEntityNotFound
+	}
+
+	*r = stored
+
+	return nil
+}
+
+func (f *fakeRegionStore) SaveGeoRegion(r *model.GeoRegion) error {
+	f.writes++
+	f.polygons[r.GeoID] = *r
+
+	return nil
+}
+
+func (f *fakeRegionStore) UpdateGeoRegion(r *model.GeoRegion) error {
+	return f.SaveGeoRegion(r)
+}
+
+// validRegionsJSONL has only valid lines, regionsJSONL adds invalid ones
+const validRegionsJSONL = `{"id":"6023099","type":"country","name":"Argentina","country_code":"AR","coordinates":{"center_longitude":-63.6,"center_latitude":-38.4},"ancestors":[{"id":"6022969","type":"continent"}],"descendants":{"city":["602962"]}}
+{"id":"602962","type":"city","name":"Buenos Aires","country_code":"ar","coordinates":{"center_longitude":-58.38,"center_latitude":-34.6,"bounding_polygon":{"type":"Polygon","coordinates":[[[-58.5,-34.7],[-58.3,-34.7],[-58.3,-34.5],[-58.5,-34.5],[-58.5,-34.7]]]}},"ancestors":[{"id":"6023099","type":"country"},{"id":"6022969","type":"continent"}],"property_ids":["2","1"]}
+{"id":"4741289","type":"airport","name":"Ezeiza","country_code":"AR","coordinates":{"center_longitude":-58.53,"center_latitude":-34.82}}
+`
+
+const regionsJSONL = validRegionsJSONL + `{"id":"","type":"city","name":"No Id","coordinates":{"center_longitude":1,"center_latitude":1}}
+not json
+`
+
+func TestRegionImporter(t *testing.T) {
+	// Given
+	store := newFakeRegionStore()
+	importer := NewRegionImporter(store, "es", false, false)
+
+	// When
+	summary, err := importer.Import(strings.NewReader(regionsJSONL))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []RegionRef{{ID: "6023099", Type: model.RegionTypeCountry}, {ID: "602962", Type: model.RegionTypeCity}}, summary.Added)
+	assert.Empty(t, summary.Changed)
+	assert.Empty(t, summary.Removed)
+	assert.Equal(t, 1, summary.Skipped)
+	require.Len(t, summary.Invalid, 2)
+	assert.Equal(t, RowError{Line: 4, Field: "id", Message: "id is required"}, summary.Invalid[0])
+	assert.Equal(t, 5, summary.Invalid[1].Line)
+
+	city := store.regions["city/602962"]
+	assert.Equal(t, "Buenos Aires", city.Name["es"])
+	assert.Equal(t, "AR", city.CountryCode)
+	assert.Equal(t, []model.Ancestor{{ID: "6023099", Type: model.RegionTypeCountry}, {ID: "6022969", Type: model.RegionTypeContinent}}, city.Ancestors)
+	assert.Equal(t, []string{"1", "2"}, city.Descendants.Accommodations)
+	assert.Equal(t, model.GeometryPolygon, store.polygons["602962"].Geometry.Type)
+	assert.Equal(t, []string{"602962"}, store.regions["country/6023099"].Descendants.Cities)
+}
+
+func TestRegionImporterIncremental(t *testing.T) {
+	// Given
+	store := newFakeRegionStore()
+	_, err := NewRegionImporter(store, "es", false, false).Import(strings.NewReader(regionsJSONL))
+	require.NoError(t, err)
+
+	city := store.regions["city/602962"]
+	city.Name["en"] = "Buenos Aires City"
+	store.regions["city/602962"] = city
+	store.regions["city/1"] = model.Region{BaseRegion: model.BaseRegion{GeoID: "1", Type: model.RegionTypeCity}}
+	store.writes = 0
+
+	update := strings.Replace(validRegionsJSONL, `"name":"Buenos Aires"`, `"name":"Ciudad de Buenos Aires"`, 1)
+
+	// When
+	summary, err := NewRegionImporter(store, "es", true, false).Import(strings.NewReader(update))
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, summary.Added)
+	assert.Equal(t, []RegionRef{{ID: "602962", Type: model.RegionTypeCity, Fields: []string{"name"}}}, summary.Changed)
+	assert.Equal(t, []RegionRef{{ID: "1", Type: model.RegionTypeCity}}, summary.Removed)
+	assert.Equal(t, 1, summary.Unchanged)
+	assert.Equal(t, 1, store.writes)
+	assert.Equal(t, []string{"1"}, store.deleted)
+
+	city = store.regions["city/602962"]
+	assert.Equal(t, "Ciudad de Buenos Aires", city.Name["es"])
+	assert.Equal(t, "Buenos Aires City", city.Name["en"])
+}
+
+func TestRegionImporterPruneWithInvalidLines(t *testing.T) {
+	// Given
+	store := newFakeRegionStore()
+	_, err := NewRegionImporter(store, "es", false, false).Import(strings.NewReader(regionsJSONL))
+	require.NoError(t, err)
+
+	store.regions["city/1"] = model.Region{BaseRegion: model.BaseRegion{GeoID: "1", Type: model.RegionTypeCity}}
+
+	invalid := strings.Replace(validRegionsJSONL, `"country_code":"ar"`, `"country_code":"zz"`, 1)
+
+	// When
+	summary, err := NewRegionImporter(store, "es", true, false).Import(strings.NewReader(invalid))
+
+	// Then
+	assert.Error(t, err)
+	require.Len(t, summary.Invalid, 1)
+	assert.Equal(t, "country_code", summary.Invalid[0].Field)
+	assert.Equal(t, []RegionRef{{ID: "1", Type: model.RegionTypeCity}}, summary.Removed, "the invalid city is not removed")
+	assert.Empty(t, store.deleted)
+	assert.Contains(t, store.regions, "city/602962")
+}
+
+func TestRegionImporterDryRun(t *testing.T) {
+	// Given
+	store := newFakeRegionStore()
+	store.regions["city/1"] = model.Region{BaseRegion: model.BaseRegion{GeoID: "1", Type: model.RegionTypeCity}}
+
+	// When
+	summary, err := NewRegionImporter(store, "es", true, true).Import(strings.NewReader(regionsJSONL))
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, summary.Added, 2)
+	assert.Len(t, summary.Removed, 1)
+	assert.Zero(t, store.writes)
+	assert.Empty(t, store.deleted)
+}
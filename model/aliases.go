@@ -0,0 +1,60 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// FillAliasNames sets the aliases of every language in one lowercase list, so that a name
+// matches an alias in any language with one indexed field
+func (r *Region) FillAliasNames() {
+	r.AliasNames = AliasNames(r.Aliases)
+}
+
+// AliasNames returns the aliases of every language lowercased, sorted and without duplicates
+func AliasNames(aliases map[Language][]string) []string {
+	seen := map[string]bool{}
+	names := make([]string, 0)
+
+	for _, list := range aliases {
+		for _, alias := range list {
+			name := strings.ToLower(alias)
+
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NormalizeAliases trims the aliases and drops the empty and repeated ones, ignoring case, and the languages left
+// without aliases
+func NormalizeAliases(aliases map[Language][]string) map[Language][]string {
+	result := make(map[Language][]string, len(aliases))
+
+	for language, list := range aliases {
+		seen := map[string]bool{}
+		kept := make([]string, 0, len(list))
+
+		for _, alias := range list {
+			alias = strings.TrimSpace(alias)
+			key := strings.ToLower(alias)
+
+			if alias != "" && !seen[key] {
+				seen[key] = true
+				kept = append(kept, alias)
+			}
+		}
+
+		if len(kept) > 0 {
+			result[language] = kept
+		}
+	}
+
+	return result
+}
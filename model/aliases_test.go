@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasNames(t *testing.T) {
+	// When
+	names := AliasNames(map[Language][]string{
+		"es": {"CABA", "Capital Federal"},
+		"en": {"caba", ""},
+		"pt": {"Baires"},
+	})
+
+	// Then
+	assert.Equal(t, []string{"baires", "caba", "capital federal"}, names)
+	assert.Empty(t, AliasNames(nil))
+}
+
+func TestNormalizeAliases(t *testing.T) {
+	// When
+	aliases := NormalizeAliases(map[Language][]string{
+		"es": {" CABA ", "Capital Federal", "caba", ""},
+		"en": {"  ", ""},
+		"pt": {"Baires"},
+	})
+
+	// Then
+	assert.Equal(t, map[Language][]string{"es": {"CABA", "Capital Federal"}, "pt": {"Baires"}}, aliases)
+	assert.Empty(t, NormalizeAliases(nil))
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BoundingBox is a rectangle in degrees, MinLongitude is greater than MaxLongitude when it crosses the antimeridian
+type BoundingBox struct {
+	MinLongitude float64 `json:"min_longitude"`
+	MinLatitude  float64 `json:"min_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+}
+
+// ParseBoundingBox parses a minLon,minLat,maxLon,maxLat bounding box
+func ParseBoundingBox(s string) (*BoundingBox, error) {
+	parts := strings.Split(s, ",")
+
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("bounding box must be minLon,minLat,maxLon,maxLat")
+	}
+
+	values := make([]float64, 0, 4)
+
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bounding box must be minLon,minLat,maxLon,maxLat")
+		}
+
+		values = append(values, v)
+	}
+
+	b := &BoundingBox{MinLongitude: values[0], MinLatitude: values[1], MaxLongitude: values[2], MaxLatitude: values[3]}
+
+	if b.MinLongitude < -180 || b.MaxLongitude > 180 || b.MinLongitude > 180 || b.MaxLongitude < -180 {
+		return nil, fmt.Errorf("bounding box longitudes must be between -180 and 180")
+	}
+
+	if b.MinLatitude < -90 || b.MaxLatitude > 90 || b.MinLatitude > b.MaxLatitude {
+		return nil, fmt.Errorf("bounding box latitudes must be between -90 and 90 and min must not be greater than max")
+	}
+
+	if b.MinLatitude == b.MaxLatitude || b.MinLongitude == b.MaxLongitude {
+		return nil, fmt.Errorf("bounding box must not be empty")
+	}
+
+	return b, nil
+}
+
+// CrossesAntimeridian returns if the box goes over the 180th meridian
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// Contains returns if the point is inside the box
+func (b BoundingBox) Contains(longitude, latitude float64) bool {
+	if latitude < b.MinLatitude || latitude > b.MaxLatitude {
+		return false
+	}
+
+	if b.CrossesAntimeridian() {
+		return longitude >= b.MinLongitude || longitude <= b.MaxLongitude
+	}
+
+	return longitude >= b.MinLongitude && longitude <= b.MaxLongitude
+}
+
+// Width returns the degrees of longitude covered by the box
+func (b BoundingBox) Width() float64 {
+	if b.CrossesAntimeridian() {
+		return 360 - b.MinLongitude + b.MaxLongitude
+	}
+
+	return b.MaxLongitude - b.MinLongitude
+}
+
+// Height returns the degrees of latitude covered by the box
+func (b BoundingBox) Height() float64 {
+	return b.MaxLatitude - b.MinLatitude
+}
+
+const (
+	// boxPieceWidth keeps every polygon of the box geometry smaller than a hemisphere
+	boxPieceWidth = 90
+
+	// boxEdgeStep adds vertices along the parallels so the geodesic edges follow them
+	boxEdgeStep = 1
+)
+
+// Geometry returns the box as a multi-polygon for spherical queries, split at the antimeridian and in pieces
+// of at most 90 degrees of longitude
+func (b BoundingBox) Geometry() *Geometry {
+	ranges := [][2]float64{{b.MinLongitude, b.MaxLongitude}}
+
+	if b.CrossesAntimeridian() {
+		ranges = [][2]float64{{b.MinLongitude, 180}, {-180, b.MaxLongitude}}
+	}
+
+	polygons := make([]interface{}, 0)
+
+	for _, r := range ranges {
+		for west := r[0]; west < r[1]; west += boxPieceWidth {
+			east := math.Min(west+boxPieceWidth, r[1])
+			polygons = append(polygons, [][][]float64{boxRing(west, east, b.MinLatitude, b.MaxLatitude)})
+		}
+	}
+
+	return &Geometry{Type: GeometryMultiPolygon, Coordinates: polygons}
+}
+
+// boxRing returns the closed ring of the box, the parallels at the poles collapse to a single vertex and the meridians
+// get a middle vertex so a box from pole to pole has no antipodal edges
+func boxRing(west, east, south, north float64) [][]float64 {
+	points := make([][]float64, 0)
+	middle := (south + north) / 2
+
+	add := func(longitude, latitude float64) {
+		if n := len(points); n > 0 {
+			last := points[n-1]
+
+			if last[1] == latitude && (last[0] == longitude || math.Abs(latitude) == 90) {
+				return
+			}
+		}
+
+		points = append(points, []float64{longitude, latitude})
+	}
+
+	for longitude := west; longitude < east; longitude += boxEdgeStep {
+		add(longitude, south)
+	}
+
+	add(east, south)
+	add(east, middle)
+
+	for longitude := east; longitude > west; longitude -= boxEdgeStep {
+		add(longitude, north)
+	}
+
+	add(west, north)
+	add(west, middle)
+	add(west, south)
+
+	return points
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBoundingBox(t *testing.T) {
+	// When
+	b, err := ParseBoundingBox("-58.53,-34.71,-58.33,-34.52")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, b.Contains(-58.38, -34.60))
+	assert.False(t, b.Contains(-57.95, -34.92))
+}
+
+func TestParseBoundingBoxAntimeridian(t *testing.T) {
+	// When
+	b, err := ParseBoundingBox("170,-20,-170,-10")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, b.CrossesAntimeridian())
+	assert.True(t, b.Contains(179, -15))
+	assert.True(t, b.Contains(-175, -15))
+	assert.False(t, b.Contains(0, -15))
+}
+
+func TestParseBoundingBoxInvalid(t *testing.T) {
+	for _, s := range []string{"", "1,2,3", "a,1,2,3", "-200,0,10,10", "0,10,10,0", "0,10,0,20"} {
+		_, err := ParseBoundingBox(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestBoundingBoxGeometry(t *testing.T) {
+	// Given
+	b := BoundingBox{MinLongitude: -58.5, MinLatitude: -34.75, MaxLongitude: -58.25, MaxLatitude: -34.25}
+
+	// When
+	g := b.Geometry()
+
+	// Then
+	assert.Equal(t, GeometryMultiPolygon, g.Type)
+	assert.Equal(t, []interface{}{[][][]float64{{{-58.5, -34.75}, {-58.25, -34.75}, {-58.25, -34.5}, {-58.25, -34.25}, {-58.5, -34.25}, {-58.5, -34.5}, {-58.5, -34.75}}}}, g.Coordinates)
+}
+
+func TestBoundingBoxGeometryAntimeridian(t *testing.T) {
+	// Given
+	b := BoundingBox{MinLongitude: 170, MinLatitude: -20, MaxLongitude: -170, MaxLatitude: -10}
+
+	// When
+	g := b.Geometry()
+
+	// Then
+	require.Len(t, g.Coordinates, 2)
+	assert.Equal(t, 20.0, b.Width())
+
+	east := g.Coordinates[0].([][][]float64)[0]
+	west := g.Coordinates[1].([][][]float64)[0]
+
+	assert.Equal(t, []float64{170, -20}, east[0])
+	assert.Equal(t, []float64{180, -20}, east[10])
+	assert.Equal(t, []float64{-180, -20}, west[0])
+	assert.Equal(t, []float64{-170, -20}, west[10])
+}
+
+func TestBoundingBoxGeometryWorld(t *testing.T) {
+	// Given
+	b := BoundingBox{MinLongitude: -180, MinLatitude: -90, MaxLongitude: 180, MaxLatitude: 90}
+
+	// When
+	g := b.Geometry()
+
+	// Then
+	require.Len(t, g.Coordinates, 4)
+
+	for _, p := range g.Coordinates {
+		ring := p.([][][]float64)[0]
+
+		assert.Len(t, ring, 5)
+		assert.Equal(t, ring[0], ring[len(ring)-1])
+
+		for i := 1; i < len(ring); i++ {
+			assert.NotEqual(t, ring[i-1], ring[i])
+		}
+	}
+}
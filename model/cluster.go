@@ -0,0 +1,40 @@
+package model
+
+import "math"
+
+// Zoom levels of the web maps, from the whole world in one tile to a few buildings
+const (
+	MinZoom = 0
+	MaxZoom = 22
+
+	// ClusterMaxZoom is the last zoom level where accommodations are clustered, from the next one they are single points
+	ClusterMaxZoom = 16
+
+	// clusterCellsPerTile splits each 256px tile in cells of 64px
+	clusterCellsPerTile = 4
+)
+
+// AccommodationCluster is a grid cell with the accommodations inside it
+type AccommodationCluster struct {
+	Count    int      `json:"count" bson:"count"`
+	Centroid Center   `json:"centroid" bson:"centroid"`
+	IDs      []string `json:"ids" bson:"ids"`
+}
+
+// ClusterCellSize returns the side in degrees of the cells used at the zoom level, 0 when points are not clustered
+func ClusterCellSize(zoom int) float64 {
+	if zoom > ClusterMaxZoom {
+		return 0
+	}
+
+	return 360 / (math.Exp2(float64(zoom)) * clusterCellsPerTile)
+}
+
+// ClusterCells returns how many cells of the size cover the bounding box
+func ClusterCells(b BoundingBox, cellSize float64) int {
+	if cellSize <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(b.Width()/cellSize) * math.Ceil(b.Height()/cellSize))
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterCellSize(t *testing.T) {
+	assert.Equal(t, 90.0, ClusterCellSize(0))
+	assert.Equal(t, 360/(1024*4.0), ClusterCellSize(10))
+	assert.Less(t, ClusterCellSize(ClusterMaxZoom), ClusterCellSize(ClusterMaxZoom-1))
+	assert.Equal(t, 0.0, ClusterCellSize(ClusterMaxZoom+1))
+}
+
+func TestClusterCells(t *testing.T) {
+	world := BoundingBox{MinLongitude: -180, MinLatitude: -90, MaxLongitude: 180, MaxLatitude: 90}
+
+	assert.Equal(t, 8, ClusterCells(world, ClusterCellSize(0)))
+	assert.Equal(t, 2, ClusterCells(BoundingBox{MinLongitude: 170, MinLatitude: -20, MaxLongitude: -170, MaxLatitude: -10}, 10))
+	assert.Equal(t, 0, ClusterCells(world, ClusterCellSize(ClusterMaxZoom+1)))
+}
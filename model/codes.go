@@ -0,0 +1,33 @@
+package model
+
+import "regexp"
+
+var (
+	iataCodeRegex    = regexp.MustCompile(`^[A-Z]{3}$`)
+	icaoCodeRegex    = regexp.MustCompile(`^[A-Z]{4}$`)
+	countryCodeRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// IsIATACode returns if the code has the shape of an IATA location code, e.g. EZE
+func IsIATACode(code string) bool {
+	return iataCodeRegex.MatchString(code)
+}
+
+// IsICAOCode returns if the code has the shape of an ICAO airport code, e.g. SAEZ
+func IsICAOCode(code string) bool {
+	return icaoCodeRegex.MatchString(code)
+}
+
+// IsCountryCode returns if the code has the shape of an ISO 3166-1 alpha-2 code, e.g. AR
+func IsCountryCode(code string) bool {
+	return countryCodeRegex.MatchString(code)
+}
+
+// IsValidCoordinate returns if the latitude and longitude are in range and are not the 0,0 placeholder
+func IsValidCoordinate(latitude, longitude float64) bool {
+	if latitude == 0 && longitude == 0 {
+		return false
+	}
+
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// CustomAreaIDPrefix keeps the ids of the custom areas apart from the curated ones
+const CustomAreaIDPrefix = "custom-"
+
+// CustomArea is a region drawn or uploaded by a team. It lives in its own collection and
+// it is never an ancestor or descendant in the curated hierarchy.
+type CustomArea struct {
+	Region   `bson:",inline"`
+	Owner    string    `json:"owner" bson:"owner"`
+	Tags     []string  `json:"tags,omitempty" bson:"tags,omitempty"`
+	Geometry *Geometry `json:"geometry,omitempty" bson:"-"`
+}
+
+// NewCustomAreaID returns a new id in the custom areas namespace
+func NewCustomAreaID() string {
+	return CustomAreaIDPrefix + bson.NewObjectId().Hex()
+}
+
+// Validate checks the fields required to save the area
+func (a *CustomArea) Validate() error {
+	if a.Owner == "" {
+		return fmt.Errorf("[owner] is required")
+	}
+
+	if len(a.Name) == 0 {
+		return fmt.Errorf("[name] is required")
+	}
+
+	if a.Geometry == nil {
+		return fmt.Errorf("[geometry] is required")
+	}
+
+	if _, err := a.Geometry.Polygons(); err != nil {
+		return fmt.Errorf("[geometry] %w", err)
+	}
+
+	return nil
+}
+
+// GeoRegion returns the polygon of the area
+func (a *CustomArea) GeoRegion() *GeoRegion {
+	return &GeoRegion{
+		BaseRegion: BaseRegion{GeoID: a.GeoID, Type: RegionTypeCustomArea},
+		Geometry:   *a.Geometry,
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomAreaValidate(t *testing.T) {
+	// Given
+	area := CustomArea{
+		Region:   Region{Name: map[Language]string{"es": "Patagonia sin Chile"}},
+		Owner:    "marketing",
+		Geometry: &Geometry{Type: GeometryPolygon, Coordinates: []interface{}{square(0, 0, 1, 1)}},
+	}
+
+	// Then
+	assert.NoError(t, area.Validate())
+
+	area.Geometry = NewPointGeometry([]interface{}{1.0, 2.0})
+	assert.Error(t, area.Validate())
+
+	area.Owner = ""
+	assert.Error(t, area.Validate())
+}
+
+func TestCustomAreaLocalize(t *testing.T) {
+	// Given
+	area := CustomArea{
+		Region: Region{BaseRegion: BaseRegion{GeoID: NewCustomAreaID()}, Name: map[Language]string{"es": "Costa", "en": "Coast"}},
+		Owner:  "marketing",
+		Tags:   []string{"beach"},
+	}
+
+	// When
+	blob, err := json.Marshal(Localize(area, []Language{"en"}))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(area.GeoID, CustomAreaIDPrefix))
+	assert.Contains(t, string(blob), `"name":"Coast"`)
+	assert.Contains(t, string(blob), `"owner":"marketing"`)
+	assert.Contains(t, string(blob), `"tags":["beach"]`)
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GeohashPrecision is the length of the stored geohashes, cells of a few centimeters
+const GeohashPrecision = 12
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash directions
+const (
+	North     = "n"
+	NorthEast = "ne"
+	East      = "e"
+	SouthEast = "se"
+	South     = "s"
+	SouthWest = "sw"
+	West      = "w"
+	NorthWest = "nw"
+)
+
+// GeohashCell is a geohash with its bounds and the cells around it
+type GeohashCell struct {
+	Geohash     string            `json:"geohash"`
+	BoundingBox BoundingBox       `json:"bbox"`
+	Neighbors   map[string]string `json:"neighbors"`
+}
+
+// GeohashEntities are the points inside a geohash cell
+type GeohashEntities struct {
+	GeohashCell
+	Regions  []GeoRegion `json:"regions"`
+	Airports []AirportV2 `json:"airports"`
+}
+
+// EncodeGeohash returns the geohash of a point with the given length
+func EncodeGeohash(latitude, longitude float64, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+
+	var sb strings.Builder
+
+	bit, ch, even := 0, 0, true
+
+	for sb.Len() < precision {
+		if even {
+			mid := (minLon + maxLon) / 2
+			if longitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLon = mid
+			} else {
+				maxLon = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if latitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			sb.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// DecodeGeohash returns the bounds of a geohash cell
+func DecodeGeohash(geohash string) (*BoundingBox, error) {
+	if geohash == "" || len(geohash) > GeohashPrecision {
+		return nil, fmt.Errorf("geohash must have between 1 and %d characters", GeohashPrecision)
+	}
+
+	b := BoundingBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180}
+	even := true
+
+	for _, c := range strings.ToLower(geohash) {
+		value := strings.IndexRune(geohashAlphabet, c)
+		if value < 0 {
+			return nil, fmt.Errorf("%c is not a valid geohash character", c)
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			on := value&(1<<bit) != 0
+
+			if even {
+				mid := (b.MinLongitude + b.MaxLongitude) / 2
+				if on {
+					b.MinLongitude = mid
+				} else {
+					b.MaxLongitude = mid
+				}
+			} else {
+				mid := (b.MinLatitude + b.MaxLatitude) / 2
+				if on {
+					b.MinLatitude = mid
+				} else {
+					b.MaxLatitude = mid
+				}
+			}
+
+			even = !even
+		}
+	}
+
+	return &b, nil
+}
+
+// NewGeohashCell decodes the geohash and finds its neighbors, there are no neighbors beyond the poles
+func NewGeohashCell(geohash string) (*GeohashCell, error) {
+	b, err := DecodeGeohash(geohash)
+	if err != nil {
+		return nil, err
+	}
+
+	geohash = strings.ToLower(geohash)
+
+	height, width := b.MaxLatitude-b.MinLatitude, b.MaxLongitude-b.MinLongitude
+	lat, lon := (b.MinLatitude+b.MaxLatitude)/2, (b.MinLongitude+b.MaxLongitude)/2
+
+	offsets := map[string][2]float64{
+		North:     {1, 0},
+		NorthEast: {1, 1},
+		East:      {0, 1},
+		SouthEast: {-1, 1},
+		South:     {-1, 0},
+		SouthWest: {-1, -1},
+		West:      {0, -1},
+		NorthWest: {1, -1},
+	}
+
+	neighbors := make(map[string]string, len(offsets))
+
+	for direction, o := range offsets {
+		nLat := lat + o[0]*height
+		if nLat > 90 || nLat < -90 {
+			continue
+		}
+
+		nLon := lon + o[1]*width
+		if nLon > 180 {
+			nLon -= 360
+		} else if nLon < -180 {
+			nLon += 360
+		}
+
+		neighbors[direction] = EncodeGeohash(nLat, nLon, len(geohash))
+	}
+
+	return &GeohashCell{Geohash: geohash, BoundingBox: *b, Neighbors: neighbors}, nil
+}
+
+// FillGeohash sets the geohash of point geometries
+func (g *GeoRegion) FillGeohash() {
+	if g.Geometry.Type != GeometryPoint {
+		return
+	}
+
+	var lon, lat float64
+	var ok bool
+
+	if len(g.Geometry.Coordinates) == 2 {
+		lon, ok = g.Geometry.Coordinates[0].(float64)
+		if ok {
+			lat, ok = g.Geometry.Coordinates[1].(float64)
+		}
+	} else if len(g.Geometry.Point) == 2 {
+		lon, lat, ok = g.Geometry.Point[0], g.Geometry.Point[1], true
+	}
+
+	if ok {
+		g.Geohash = EncodeGeohash(lat, lon, GeohashPrecision)
+	}
+}
+
+// FillGeohash sets the geohash of the airport coordinates, unless they are missing
+func (a *AirportV2) FillGeohash() {
+	if a.Coordinates.Latitude == 0 && a.Coordinates.Longitude == 0 {
+		return
+	}
+
+	a.Geohash = EncodeGeohash(a.Coordinates.Latitude, a.Coordinates.Longitude, GeohashPrecision)
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	assert.Equal(t, "ezs42", EncodeGeohash(42.6, -5.6, 5))
+
+	b, err := DecodeGeohash(EncodeGeohash(-34.6037, -58.3816, 9))
+	require.NoError(t, err)
+	assert.True(t, b.Contains(-58.3816, -34.6037))
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	// When
+	b, err := DecodeGeohash("ezs42")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, b.Contains(-5.6, 42.6))
+	assert.InDelta(t, 360/8192.0, b.MaxLongitude-b.MinLongitude, 1e-12)
+	assert.InDelta(t, 180/4096.0, b.MaxLatitude-b.MinLatitude, 1e-12)
+
+	_, err = DecodeGeohash("ezs4a")
+	assert.Error(t, err)
+}
+
+func TestNewGeohashCell(t *testing.T) {
+	// When
+	cell, err := NewGeohashCell("ezs42")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		North:     "ezs48",
+		NorthEast: "ezs49",
+		East:      "ezs43",
+		SouthEast: "ezs41",
+		South:     "ezs40",
+		SouthWest: "ezefp",
+		West:      "ezefr",
+		NorthWest: "ezefx",
+	}, cell.Neighbors)
+}
+
+func TestNewGeohashCellAtTheEdges(t *testing.T) {
+	// When
+	cell, err := NewGeohashCell("b")
+
+	// Then
+	require.NoError(t, err)
+	assert.NotContains(t, cell.Neighbors, North)
+	assert.Equal(t, "z", cell.Neighbors[West])
+}
+
+func TestGeoRegionFillGeohash(t *testing.T) {
+	g := GeoRegion{Geometry: *NewPointGeometry([]interface{}{-58.3816, -34.6037})}
+	g.FillGeohash()
+	assert.Equal(t, EncodeGeohash(-34.6037, -58.3816, GeohashPrecision), g.Geohash)
+
+	polygon := GeoRegion{Geometry: Geometry{Type: GeometryPolygon}}
+	polygon.FillGeohash()
+	assert.Empty(t, polygon.Geohash)
+}
@@ -0,0 +1,642 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// GeometryOperation is a set operation between geometries
+type GeometryOperation string
+
+// Supported geometry operations
+const (
+	GeometryOperationUnion        GeometryOperation = "union"
+	GeometryOperationIntersection GeometryOperation = "intersection"
+	GeometryOperationDifference   GeometryOperation = "difference"
+	GeometryOperationBuffer       GeometryOperation = "buffer"
+)
+
+// GeometryOperand is a stored polygon, by its geo_id, or an ad-hoc geometry
+type GeometryOperand struct {
+	GeoID    string    `json:"geo_id,omitempty"`
+	Geometry *Geometry `json:"geometry,omitempty"`
+}
+
+// GeometryOpsRequest applies the operation to the operands in order, e.g. the first one minus the rest for a difference.
+// A buffer grows the union of the operands by DistanceKm. When Persist is set the result is saved as a new GeoRegion.
+type GeometryOpsRequest struct {
+	Operation  GeometryOperation `json:"operation"`
+	Operands   []GeometryOperand `json:"operands"`
+	DistanceKm float64           `json:"distance_km,omitempty"`
+	Persist    *BaseRegion       `json:"persist,omitempty"`
+}
+
+// Limits of the vertices of all the operands, a buffer adds a circle around each vertex so it takes fewer
+const (
+	MaxGeometryOpsVertices = 50000
+	MaxBufferVertices      = 5000
+)
+
+// ApplyGeometryOperation runs the operation over Polygon and MultiPolygon geometries.
+// Coordinates are treated as planar, except for the buffer distance which is converted at the latitude of the shapes.
+func ApplyGeometryOperation(op GeometryOperation, geometries []Geometry, distanceKm float64) (*Geometry, error) {
+	operands := make([][][][][]float64, 0, len(geometries))
+	vertices := 0
+
+	for _, g := range geometries {
+		polygons, err := g.Polygons()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				vertices += len(ring)
+			}
+		}
+
+		operands = append(operands, polygons)
+	}
+
+	limit := MaxGeometryOpsVertices
+
+	if op == GeometryOperationBuffer {
+		limit = MaxBufferVertices
+	}
+
+	if vertices > limit {
+		return nil, fmt.Errorf("the operands of the %s have %d vertices, more than %d", op, vertices, limit)
+	}
+
+	var result [][][][]float64
+
+	switch op {
+	case GeometryOperationUnion, GeometryOperationIntersection, GeometryOperationDifference:
+		if len(operands) < 2 {
+			return nil, fmt.Errorf("%s needs at least 2 geometries", op)
+		}
+
+		result = clipPolygons(operands, insidePredicate(op))
+	case GeometryOperationBuffer:
+		if len(operands) == 0 {
+			return nil, fmt.Errorf("buffer needs a geometry")
+		}
+
+		if distanceKm <= 0 {
+			return nil, fmt.Errorf("buffer distance must be positive")
+		}
+
+		result = bufferPolygons(operands, distanceKm)
+	default:
+		return nil, fmt.Errorf("%s is not a valid operation", op)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("the result of the %s is empty", op)
+	}
+
+	return newPolygonalGeometry(result), nil
+}
+
+func insidePredicate(op GeometryOperation) func(inside []bool) bool {
+	switch op {
+	case GeometryOperationIntersection:
+		return func(inside []bool) bool {
+			for _, in := range inside {
+				if !in {
+					return false
+				}
+			}
+
+			return true
+		}
+	case GeometryOperationDifference:
+		return func(inside []bool) bool {
+			for _, in := range inside[1:] {
+				if in {
+					return false
+				}
+			}
+
+			return inside[0]
+		}
+	}
+
+	return func(inside []bool) bool {
+		for _, in := range inside {
+			if in {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// newPolygonalGeometry builds a Polygon, or a MultiPolygon when there are many, with the coordinates stored in mongo
+func newPolygonalGeometry(polygons [][][][]float64) *Geometry {
+	coordinates := make([]interface{}, 0, len(polygons))
+
+	for _, polygon := range polygons {
+		rings := make([]interface{}, 0, len(polygon))
+
+		for _, ring := range polygon {
+			positions := make([]interface{}, 0, len(ring))
+
+			for _, p := range ring {
+				positions = append(positions, []interface{}{p[0], p[1]})
+			}
+
+			rings = append(rings, positions)
+		}
+
+		coordinates = append(coordinates, rings)
+	}
+
+	if len(polygons) == 1 {
+		return &Geometry{Type: GeometryPolygon, Coordinates: coordinates[0].([]interface{})}
+	}
+
+	return &Geometry{Type: GeometryMultiPolygon, Coordinates: coordinates}
+}
+
+// clipEdge is a directed edge of an operand, outer rings are counterclockwise and holes clockwise
+type clipEdge struct {
+	a, b    [2]float64
+	operand int
+	splits  [][2]float64
+}
+
+func (e *clipEdge) minX() float64 { return math.Min(e.a[0], e.b[0]) }
+func (e *clipEdge) maxX() float64 { return math.Max(e.a[0], e.b[0]) }
+func (e *clipEdge) minY() float64 { return math.Min(e.a[1], e.b[1]) }
+func (e *clipEdge) maxY() float64 { return math.Max(e.a[1], e.b[1]) }
+
+// clipPolygons computes the boundary of the area where keep is true.
+// The edges of all the operands are split where they cross, then an edge is kept when the area is kept on one side
+// of it but not on the other, oriented so the kept side is on its left. Inside means a non zero winding number,
+// so the polygons of an operand may overlap.
+func clipPolygons(operands [][][][][]float64, keep func(inside []bool) bool) [][][][]float64 {
+	edges := make([]*clipEdge, 0)
+
+	for i, polygons := range operands {
+		for _, polygon := range polygons {
+			for j, ring := range polygon {
+				edges = append(edges, ringEdges(ring, i, j == 0)...)
+			}
+		}
+	}
+
+	splitEdges(edges)
+
+	index := newWindingIndex(edges, len(operands))
+	inside := make([]bool, len(operands))
+
+	result := make([][2][2]float64, 0)
+	seen := map[[2][2]float64]bool{}
+
+	for _, e := range edges {
+		points := append(append([][2]float64{e.a}, e.splits...), e.b)
+
+		for i := 1; i < len(points); i++ {
+			p, q := points[i-1], points[i]
+
+			dx, dy := q[0]-p[0], q[1]-p[1]
+			length := math.Hypot(dx, dy)
+
+			if length == 0 {
+				continue
+			}
+
+			// Sample both sides of the middle of the edge
+			eps := math.Min(length/1000, 1e-8)
+			mx, my := (p[0]+q[0])/2, (p[1]+q[1])/2
+			nx, ny := -dy/length*eps, dx/length*eps
+
+			index.inside(mx+nx, my+ny, inside)
+			left := keep(inside)
+
+			index.inside(mx-nx, my-ny, inside)
+			right := keep(inside)
+
+			var edge [2][2]float64
+
+			switch {
+			case left && !right:
+				edge = [2][2]float64{p, q}
+			case right && !left:
+				edge = [2][2]float64{q, p}
+			default:
+				continue
+			}
+
+			// Shared boundaries produce the same edge once per operand
+			if !seen[edge] {
+				seen[edge] = true
+				result = append(result, edge)
+			}
+		}
+	}
+
+	return assemblePolygons(result)
+}
+
+// ringEdges returns the edges of a ring with outer rings counterclockwise and holes clockwise, it may be unclosed
+func ringEdges(ring [][]float64, operand int, outer bool) []*clipEdge {
+	signed := 0.0
+
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+		signed += p1[0]*p2[1] - p2[0]*p1[1]
+	}
+
+	edges := make([]*clipEdge, 0, len(ring))
+
+	if signed == 0 {
+		return edges
+	}
+
+	reverse := (signed > 0) != outer
+
+	for i := range ring {
+		a := snap(ring[i][0], ring[i][1])
+		b := snap(ring[(i+1)%len(ring)][0], ring[(i+1)%len(ring)][1])
+
+		if a == b {
+			continue
+		}
+
+		if reverse {
+			a, b = b, a
+		}
+
+		edges = append(edges, &clipEdge{a: a, b: b, operand: operand})
+	}
+
+	return edges
+}
+
+// splitEdges finds the crossings between every pair of edges with a sweep over the x axis.
+// The active edges are sorted by their right end, so the ones behind the sweep line are dropped from the front.
+// A crossing point is shared by both edges so the pieces have exactly the same end points.
+func splitEdges(edges []*clipEdge) {
+	sorted := append([]*clipEdge{}, edges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].minX() < sorted[j].minX() })
+
+	active := make([]*clipEdge, 0)
+
+	for _, e := range sorted {
+		x := e.minX()
+		active = active[sort.Search(len(active), func(i int) bool { return active[i].maxX() >= x }):]
+
+		for _, other := range active {
+			if other.maxY() >= e.minY() && other.minY() <= e.maxY() {
+				crossEdges(e, other)
+			}
+		}
+
+		end := e.maxX()
+		at := sort.Search(len(active), func(i int) bool { return active[i].maxX() > end })
+
+		active = append(active, nil)
+		copy(active[at+1:], active[at:])
+		active[at] = e
+	}
+
+	for _, e := range edges {
+		if len(e.splits) == 0 {
+			continue
+		}
+
+		a := e.a
+
+		sort.Slice(e.splits, func(i, j int) bool {
+			return sqDistance(a, e.splits[i]) < sqDistance(a, e.splits[j])
+		})
+
+		unique := e.splits[:0]
+
+		for _, p := range e.splits {
+			if p != a && p != e.b && (len(unique) == 0 || unique[len(unique)-1] != p) {
+				unique = append(unique, p)
+			}
+		}
+
+		e.splits = unique
+	}
+}
+
+// snapGrid is the precision of the positions, about a tenth of millimeter.
+// Without it almost equal positions make edges too short to tell their sides apart.
+const snapGrid = 1e9
+
+func snap(x, y float64) [2]float64 {
+	return [2]float64{math.Round(x*snapGrid) / snapGrid, math.Round(y*snapGrid) / snapGrid}
+}
+
+func sqDistance(a, b [2]float64) float64 {
+	return (a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1])
+}
+
+func cross(ox, oy, ax, ay, bx, by float64) float64 {
+	return (ax-ox)*(by-oy) - (ay-oy)*(bx-ox)
+}
+
+func crossEdges(s, t *clipEdge) {
+	const eps = 1e-12
+
+	rx, ry := s.b[0]-s.a[0], s.b[1]-s.a[1]
+	qx, qy := t.b[0]-t.a[0], t.b[1]-t.a[1]
+
+	denom := rx*qy - ry*qx
+
+	if denom == 0 {
+		if cross(s.a[0], s.a[1], s.b[0], s.b[1], t.a[0], t.a[1]) != 0 {
+			return
+		}
+
+		// Collinear, each edge is split at the end points of the other one that lie on it
+		for _, p := range [][2]float64{t.a, t.b} {
+			if withinCollinear(s, p) {
+				s.splits = append(s.splits, p)
+			}
+		}
+
+		for _, p := range [][2]float64{s.a, s.b} {
+			if withinCollinear(t, p) {
+				t.splits = append(t.splits, p)
+			}
+		}
+
+		return
+	}
+
+	wx, wy := t.a[0]-s.a[0], t.a[1]-s.a[1]
+	u := (wx*qy - wy*qx) / denom
+	v := (wx*ry - wy*rx) / denom
+
+	if u < -eps || u > 1+eps || v < -eps || v > 1+eps {
+		return
+	}
+
+	// Snap to the end points so touching edges share them exactly
+	var p [2]float64
+
+	switch {
+	case u <= eps:
+		p = s.a
+	case u >= 1-eps:
+		p = s.b
+	case v <= eps:
+		p = t.a
+	case v >= 1-eps:
+		p = t.b
+	default:
+		p = snap(s.a[0]+u*rx, s.a[1]+u*ry)
+	}
+
+	s.splits = append(s.splits, p)
+	t.splits = append(t.splits, p)
+}
+
+func withinCollinear(e *clipEdge, p [2]float64) bool {
+	return p[0] >= e.minX() && p[0] <= e.maxX() && p[1] >= e.minY() && p[1] <= e.maxY()
+}
+
+// windingIndex buckets the edges in horizontal bands to compute winding numbers without visiting every edge
+type windingIndex struct {
+	minY, height float64
+	bands        [][]*clipEdge
+	operands     int
+}
+
+func newWindingIndex(edges []*clipEdge, operands int) *windingIndex {
+	minY, maxY := math.Inf(1), math.Inf(-1)
+
+	for _, e := range edges {
+		minY, maxY = math.Min(minY, e.minY()), math.Max(maxY, e.maxY())
+	}
+
+	count := int(math.Sqrt(float64(len(edges)))) + 1
+	index := &windingIndex{minY: minY, height: (maxY - minY) / float64(count), bands: make([][]*clipEdge, count), operands: operands}
+
+	for _, e := range edges {
+		for b := index.band(e.minY()); b <= index.band(e.maxY()); b++ {
+			index.bands[b] = append(index.bands[b], e)
+		}
+	}
+
+	return index
+}
+
+func (w *windingIndex) band(y float64) int {
+	if w.height <= 0 || math.IsInf(w.minY, 0) {
+		return 0
+	}
+
+	b := int((y - w.minY) / w.height)
+
+	if b < 0 {
+		return 0
+	}
+
+	if b >= len(w.bands) {
+		return len(w.bands) - 1
+	}
+
+	return b
+}
+
+// inside sets if the point is inside each operand
+func (w *windingIndex) inside(x, y float64, result []bool) {
+	winding := make([]int, w.operands)
+
+	if len(w.bands) > 0 && y >= w.minY && y <= w.minY+w.height*float64(len(w.bands)) {
+		for _, e := range w.bands[w.band(y)] {
+			if e.a[1] <= y {
+				if e.b[1] > y && cross(e.a[0], e.a[1], e.b[0], e.b[1], x, y) > 0 {
+					winding[e.operand]++
+				}
+			} else if e.b[1] <= y && cross(e.a[0], e.a[1], e.b[0], e.b[1], x, y) < 0 {
+				winding[e.operand]--
+			}
+		}
+	}
+
+	for i := range result {
+		result[i] = winding[i] != 0
+	}
+}
+
+// assemblePolygons chains the edges into rings and puts each hole into the smallest outer ring that contains it
+func assemblePolygons(edges [][2][2]float64) [][][][]float64 {
+	from := make(map[[2]float64][]int, len(edges))
+
+	for i, e := range edges {
+		from[e[0]] = append(from[e[0]], i)
+	}
+
+	used := make([]bool, len(edges))
+
+	var outers, holes [][][]float64
+
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+
+		start := edges[i][0]
+		ring := [][]float64{{start[0], start[1]}}
+		current := i
+		closed := false
+
+		for !closed {
+			used[current] = true
+			end := edges[current][1]
+			ring = append(ring, []float64{end[0], end[1]})
+
+			if end == start {
+				closed = true
+				break
+			}
+
+			next := -1
+
+			for _, candidate := range from[end] {
+				if !used[candidate] {
+					next = candidate
+					break
+				}
+			}
+
+			if next < 0 {
+				break
+			}
+
+			current = next
+		}
+
+		if !closed || len(ring) < 4 {
+			continue
+		}
+
+		signed := 0.0
+		for j := 1; j < len(ring); j++ {
+			signed += ring[j-1][0]*ring[j][1] - ring[j][0]*ring[j-1][1]
+		}
+
+		if signed > 0 {
+			outers = append(outers, ring)
+		} else if signed < 0 {
+			holes = append(holes, ring)
+		}
+	}
+
+	polygons := make([][][][]float64, 0, len(outers))
+	areas := make([]float64, 0, len(outers))
+
+	for _, outer := range outers {
+		area, _, _ := ringCentroid(outer)
+		polygons = append(polygons, [][][]float64{outer})
+		areas = append(areas, area)
+	}
+
+	for _, hole := range holes {
+		best := -1
+
+		for i, outer := range outers {
+			if pointInRing(hole, outer) && (best < 0 || areas[i] < areas[best]) {
+				best = i
+			}
+		}
+
+		if best >= 0 {
+			polygons[best] = append(polygons[best], hole)
+		}
+	}
+
+	return polygons
+}
+
+// pointInRing checks the middle of the first edge of the hole, its vertices may touch the outer ring
+func pointInRing(hole [][]float64, ring [][]float64) bool {
+	x, y := (hole[0][0]+hole[1][0])/2, (hole[0][1]+hole[1][1])/2
+
+	return pointToPolygonDistance(x, y, [][][]float64{ring}) > 0
+}
+
+// bufferPolygons grows the operands by the distance adding a rectangle around each edge and a circle around each vertex.
+// Shapes are scaled by the cosine of their mean latitude so the distance is the same in every direction.
+func bufferPolygons(operands [][][][][]float64, distanceKm float64) [][][][]float64 {
+	const circleSegments = 16
+
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+
+	for _, polygons := range operands {
+		for _, polygon := range polygons {
+			for _, p := range polygon[0] {
+				minLat, maxLat = math.Min(minLat, p[1]), math.Max(maxLat, p[1])
+			}
+		}
+	}
+
+	scale := math.Max(math.Cos(radians((minLat+maxLat)/2)), 0.01)
+	d := distanceKm / (earthRadiusKm * math.Pi / 180)
+
+	pieces := make([][][][]float64, 0)
+
+	for _, polygons := range operands {
+		for _, polygon := range polygons {
+			scaled := make([][][]float64, 0, len(polygon))
+
+			for _, ring := range polygon {
+				r := make([][]float64, 0, len(ring))
+
+				for _, p := range ring {
+					r = append(r, []float64{p[0] * scale, p[1]})
+				}
+
+				scaled = append(scaled, r)
+
+				for i := 1; i < len(r); i++ {
+					p, q := r[i-1], r[i]
+					length := math.Hypot(q[0]-p[0], q[1]-p[1])
+
+					if length == 0 {
+						continue
+					}
+
+					nx, ny := -(q[1]-p[1])/length*d, (q[0]-p[0])/length*d
+
+					pieces = append(pieces, [][][]float64{{
+						{p[0] - nx, p[1] - ny}, {q[0] - nx, q[1] - ny}, {q[0] + nx, q[1] + ny}, {p[0] + nx, p[1] + ny}, {p[0] - nx, p[1] - ny},
+					}})
+
+					circle := make([][]float64, 0, circleSegments+1)
+
+					for s := 0; s <= circleSegments; s++ {
+						angle := 2 * math.Pi * float64(s%circleSegments) / circleSegments
+						circle = append(circle, []float64{q[0] + d*math.Cos(angle), q[1] + d*math.Sin(angle)})
+					}
+
+					pieces = append(pieces, [][][]float64{circle})
+				}
+			}
+
+			pieces = append(pieces, scaled)
+		}
+	}
+
+	result := clipPolygons([][][][][]float64{pieces}, func(inside []bool) bool { return inside[0] })
+
+	for _, polygon := range result {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				p[0] /= scale
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func polygonGeometry(rings ...[]interface{}) Geometry {
+	coordinates := make([]interface{}, 0, len(rings))
+
+	for _, r := range rings {
+		coordinates = append(coordinates, r)
+	}
+
+	return Geometry{Type: GeometryPolygon, Coordinates: coordinates}
+}
+
+func planarArea(t *testing.T, g *Geometry) float64 {
+	polygons, err := g.Polygons()
+	require.NoError(t, err)
+
+	total := 0.0
+
+	for _, polygon := range polygons {
+		for i, ring := range polygon {
+			area, _, _ := ringCentroid(ring)
+
+			if i > 0 {
+				area = -area
+			}
+
+			total += area
+		}
+	}
+
+	return total
+}
+
+func TestApplyGeometryOperationUnion(t *testing.T) {
+	// When
+	g, err := ApplyGeometryOperation(GeometryOperationUnion, []Geometry{
+		polygonGeometry(square(0, 0, 2, 2)),
+		polygonGeometry(square(1, 1, 3, 3)),
+		polygonGeometry(square(10, 10, 11, 11)),
+	}, 0)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, GeometryMultiPolygon, g.Type)
+	assert.InDelta(t, 8, planarArea(t, g), 1e-9)
+}
+
+func TestApplyGeometryOperationIntersection(t *testing.T) {
+	// When
+	g, err := ApplyGeometryOperation(GeometryOperationIntersection, []Geometry{
+		polygonGeometry(square(0, 0, 2, 2)),
+		polygonGeometry(square(1, 1, 3, 3)),
+	}, 0)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, GeometryPolygon, g.Type)
+	assert.InDelta(t, 1, planarArea(t, g), 1e-9)
+}
+
+func TestApplyGeometryOperationDifferenceMakesHole(t *testing.T) {
+	// When
+	g, err := ApplyGeometryOperation(GeometryOperationDifference, []Geometry{
+		polygonGeometry(square(0, 0, 4, 4)),
+		polygonGeometry(square(1, 1, 2, 2)),
+		polygonGeometry(square(3, 0, 5, 4)),
+	}, 0)
+
+	// Then
+	require.NoError(t, err)
+
+	polygons, err := g.Polygons()
+	require.NoError(t, err)
+	require.Len(t, polygons, 1)
+	assert.Len(t, polygons[0], 2)
+	assert.InDelta(t, 11, planarArea(t, g), 1e-9)
+}
+
+func TestApplyGeometryOperationSharedEdges(t *testing.T) {
+	// When
+	union, err := ApplyGeometryOperation(GeometryOperationUnion, []Geometry{
+		polygonGeometry(square(0, 0, 1, 1)),
+		polygonGeometry(square(1, 0, 2, 1)),
+	}, 0)
+	require.NoError(t, err)
+
+	_, err = ApplyGeometryOperation(GeometryOperationIntersection, []Geometry{
+		polygonGeometry(square(0, 0, 1, 1)),
+		polygonGeometry(square(1, 0, 2, 1)),
+	}, 0)
+
+	// Then
+	assert.Equal(t, GeometryPolygon, union.Type)
+	assert.InDelta(t, 2, planarArea(t, union), 1e-9)
+	assert.Error(t, err)
+}
+
+func TestApplyGeometryOperationBuffer(t *testing.T) {
+	// Given
+	square := polygonGeometry(square(0, 0, 1, 1))
+
+	// When
+	g, err := ApplyGeometryOperation(GeometryOperationBuffer, []Geometry{square}, 10)
+
+	// Then
+	require.NoError(t, err)
+
+	m, err := NewPolygonMetrics(*g)
+	require.NoError(t, err)
+
+	// Square plus 4 rectangles of 111.2x10 km plus a circle of 10 km radius
+	assert.InDelta(t, 12364+4*1112+314, m.AreaKm2, 60)
+	assert.InDelta(t, 0.5, m.Centroid.Longitude, 1e-3)
+
+	_, err = ApplyGeometryOperation(GeometryOperationBuffer, []Geometry{square}, -1)
+	assert.Error(t, err)
+}
+
+func TestApplyGeometryOperationInvalid(t *testing.T) {
+	_, err := ApplyGeometryOperation("xor", []Geometry{polygonGeometry(square(0, 0, 1, 1))}, 0)
+	assert.Error(t, err)
+
+	_, err = ApplyGeometryOperation(GeometryOperationUnion, []Geometry{polygonGeometry(square(0, 0, 1, 1))}, 0)
+	assert.Error(t, err)
+
+	_, err = ApplyGeometryOperation(GeometryOperationUnion, []Geometry{*NewPointGeometry([]interface{}{1.0, 2.0})}, 0)
+	assert.Error(t, err)
+}
+
+func TestApplyGeometryOperationTooManyVertices(t *testing.T) {
+	// Given
+	ring := make([]interface{}, 0, MaxBufferVertices+1)
+
+	for i := 0; i < MaxBufferVertices; i++ {
+		angle := 2 * math.Pi * float64(i) / MaxBufferVertices
+		ring = append(ring, []interface{}{math.Cos(angle), math.Sin(angle)})
+	}
+
+	ring = append(ring, ring[0])
+	circle := polygonGeometry(ring)
+
+	// When
+	_, err := ApplyGeometryOperation(GeometryOperationBuffer, []Geometry{circle}, 1)
+
+	// Then
+	assert.EqualError(t, err, fmt.Sprintf("the operands of the buffer have %d vertices, more than %d", MaxBufferVertices+1, MaxBufferVertices))
+
+	_, err = ApplyGeometryOperation(GeometryOperationUnion, []Geometry{circle, polygonGeometry(square(0, 0, 2, 2))}, 0)
+	assert.NoError(t, err)
+}
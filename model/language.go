@@ -0,0 +1,302 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage returns the languages of an Accept-Language header ordered by preference
+func ParseAcceptLanguage(header string) []Language {
+	type weighted struct {
+		lang Language
+		q    float64
+	}
+
+	var langs []weighted
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > 0 {
+			langs = append(langs, weighted{lang: Language(tag), q: q})
+		}
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	result := make([]Language, 0, len(langs))
+	for _, l := range langs {
+		result = append(result, l.lang)
+	}
+
+	return result
+}
+
+// LanguageChain builds the lookup order for localized names.
+// Every requested language is followed by its base language (pt-BR -> pt) and the fallback languages go last.
+func LanguageChain(requested []Language, fallback []Language) []Language {
+	chain := make([]Language, 0, 2*len(requested)+len(fallback))
+	seen := map[string]bool{}
+
+	add := func(l Language) {
+		key := strings.ToLower(string(l))
+		if key == "" || seen[key] {
+			return
+		}
+
+		seen[key] = true
+		chain = append(chain, l)
+	}
+
+	for _, l := range requested {
+		add(l)
+
+		if i := strings.IndexAny(string(l), "-_"); i > 0 {
+			add(l[:i])
+		}
+	}
+
+	for _, l := range fallback {
+		add(l)
+	}
+
+	return chain
+}
+
+// LocalizedName returns the name in the first language of the chain with a translation.
+// When none of them is translated it returns any available name so labels are never blank.
+func LocalizedName(names map[Language]string, chain []Language) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	for _, l := range chain {
+		if n, ok := names[l]; ok && n != "" {
+			return n
+		}
+
+		for k, n := range names {
+			if n != "" && strings.EqualFold(string(k), string(l)) {
+				return n
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(names))
+
+	for k, n := range names {
+		if n != "" {
+			keys = append(keys, string(k))
+		}
+	}
+
+	if len(keys) == 0 {
+		return ""
+	}
+
+	sort.Strings(keys)
+
+	return names[Language(keys[0])]
+}
+
+// Localizable is implemented by entities with localized names
+type Localizable interface {
+	Localize(chain []Language) interface{}
+}
+
+// Localize projects the names of a Localizable, or of a slice or map of them, to a single string
+func Localize(data interface{}, chain []Language) interface{} {
+	if l, ok := data.(Localizable); ok {
+		return l.Localize(chain)
+	}
+
+	v := reflect.ValueOf(data)
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return data
+		}
+
+		if l, ok := v.Elem().Interface().(Localizable); ok {
+			return l.Localize(chain)
+		}
+	case reflect.Slice, reflect.Array:
+		result := make([]interface{}, 0, v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			result = append(result, Localize(v.Index(i).Interface(), chain))
+		}
+
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			result[iter.Key().String()] = Localize(iter.Value().Interface(), chain)
+		}
+
+		return result
+	}
+
+	return data
+}
+
+// LocalizedRegion is a Region with its name in a single language
+type LocalizedRegion struct {
+	Region
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (r Region) Localize(chain []Language) interface{} {
+	return LocalizedRegion{Region: r, Name: LocalizedName(r.Name, chain)}
+}
+
+// LocalizedCustomArea is a CustomArea with its name in a single language
+type LocalizedCustomArea struct {
+	CustomArea
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (a CustomArea) Localize(chain []Language) interface{} {
+	return LocalizedCustomArea{CustomArea: a, Name: LocalizedName(a.Name, chain)}
+}
+
+// LocalizedGeohashEntities are GeohashEntities with the names of the airports in a single language
+type LocalizedGeohashEntities struct {
+	GeohashEntities
+	Airports interface{} `json:"airports"`
+}
+
+// Localize implements Localizable
+func (e GeohashEntities) Localize(chain []Language) interface{} {
+	return LocalizedGeohashEntities{GeohashEntities: e, Airports: Localize(e.Airports, chain)}
+}
+
+// LocalizedAirportRegion is an AirportRegion with its name in a single language
+type LocalizedAirportRegion struct {
+	AirportRegion
+	Name string `json:"name"`
+}
+
+// LocalizedAirportV2 is an AirportV2 with its names in a single language
+type LocalizedAirportV2 struct {
+	AirportV2
+	Name   string                 `json:"name"`
+	Region LocalizedAirportRegion `json:"region"`
+}
+
+// Localize implements Localizable
+func (a AirportV2) Localize(chain []Language) interface{} {
+	return LocalizedAirportV2{
+		AirportV2: a,
+		Name:      LocalizedName(a.Name, chain),
+		Region: LocalizedAirportRegion{
+			AirportRegion: a.Region,
+			Name:          LocalizedName(a.Region.Name, chain),
+		},
+	}
+}
+
+// LocalizedBaseEntity is a BaseEntity with its name in a single language
+type LocalizedBaseEntity struct {
+	BaseEntity
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (e BaseEntity) Localize(chain []Language) interface{} {
+	return LocalizedBaseEntity{BaseEntity: e, Name: LocalizedName(e.Name, chain)}
+}
+
+// LocalizedCountry is a Country with its names in a single language
+type LocalizedCountry struct {
+	Country
+	Name         string `json:"name"`
+	OfficialName string `json:"official_name,omitempty"`
+}
+
+// Localize implements Localizable
+func (c Country) Localize(chain []Language) interface{} {
+	return LocalizedCountry{
+		Country:      c,
+		Name:         LocalizedName(c.Name, chain),
+		OfficialName: LocalizedName(c.OfficialName, chain),
+	}
+}
+
+// LocalizedCity is a City with its name in a single language
+type LocalizedCity struct {
+	City
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (c City) Localize(chain []Language) interface{} {
+	return LocalizedCity{City: c, Name: LocalizedName(c.Name, chain)}
+}
+
+// LocalizedState is a State with its name in a single language
+type LocalizedState struct {
+	State
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (s State) Localize(chain []Language) interface{} {
+	return LocalizedState{State: s, Name: LocalizedName(s.Name, chain)}
+}
+
+// LocalizedNeighbourhood is a Neighbourhood with its name in a single language
+type LocalizedNeighbourhood struct {
+	Neighbourhood
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (n Neighbourhood) Localize(chain []Language) interface{} {
+	return LocalizedNeighbourhood{Neighbourhood: n, Name: LocalizedName(n.Name, chain)}
+}
+
+// LocalizedAirport is an Airport with its name in a single language
+type LocalizedAirport struct {
+	Airport
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (a Airport) Localize(chain []Language) interface{} {
+	return LocalizedAirport{Airport: a, Name: LocalizedName(a.Name, chain)}
+}
+
+// LocalizedAccommodation is an Accommodation with its name in a single language
+type LocalizedAccommodation struct {
+	Accommodation
+	Name string `json:"name"`
+}
+
+// Localize implements Localizable
+func (a Accommodation) Localize(chain []Language) interface{} {
+	return LocalizedAccommodation{Accommodation: a, Name: LocalizedName(a.Name, chain)}
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	// Given
+	header := "es;q=0.8, pt-BR, en;q=0.5, *;q=0.1"
+
+	// When
+	langs := ParseAcceptLanguage(header)
+
+	// Then
+	assert.Equal(t, []Language{"pt-BR", "es", "en"}, langs)
+}
+
+func TestLanguageChain(t *testing.T) {
+	// Given
+	requested := []Language{"pt-BR"}
+	fallback := []Language{"es", "en", "pt"}
+
+	// When
+	chain := LanguageChain(requested, fallback)
+
+	// Then
+	assert.Equal(t, []Language{"pt-BR", "pt", "es", "en"}, chain)
+}
+
+func TestLocalizedNameFallback(t *testing.T) {
+	// Given
+	names := map[Language]string{"es": "Buenos Aires", "EN": "Buenos Aires City"}
+
+	// Then
+	assert.Equal(t, "Buenos Aires", LocalizedName(names, []Language{"pt-BR", "pt", "es", "en"}))
+	assert.Equal(t, "Buenos Aires City", LocalizedName(names, []Language{"en"}))
+	assert.Equal(t, "Buenos Aires City", LocalizedName(names, []Language{"fr"}))
+	assert.Equal(t, "", LocalizedName(nil, []Language{"es"}))
+}
+
+func TestLocalizeRegionSlice(t *testing.T) {
+	// Given
+	regions := []Region{{Name: map[Language]string{"es": "Lima", "en": "Lima City"}}}
+
+	// When
+	blob, err := json.Marshal(Localize(regions, []Language{"en"}))
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, string(blob), `"name":"Lima City"`)
+}
@@ -0,0 +1,411 @@
+package model
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// earthRadiusKm is the mean radius of the earth
+const earthRadiusKm = 6371.0088
+
+// PolygonMetrics are the measures of a polygon, area and perimeter are geodesic
+type PolygonMetrics struct {
+	GeoID       string      `json:"id"`
+	AreaKm2     float64     `json:"area_km2"`
+	PerimeterKm float64     `json:"perimeter_km"`
+	Centroid    Center      `json:"centroid"`
+	BoundingBox BoundingBox `json:"bbox"`
+	LabelPoint  Center      `json:"label_point"`
+}
+
+// NewPolygonMetrics measures a Polygon or MultiPolygon geometry.
+// The label point is the pole of inaccessibility of the largest polygon, the point inside it farthest from its edges.
+func NewPolygonMetrics(g Geometry) (*PolygonMetrics, error) {
+	polygons, err := g.Polygons()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &PolygonMetrics{
+		BoundingBox: BoundingBox{MinLongitude: 180, MinLatitude: 90, MaxLongitude: -180, MaxLatitude: -90},
+	}
+
+	largest, largestArea := -1, -1.0
+
+	for i, polygon := range polygons {
+		area := polygonArea(polygon)
+		m.AreaKm2 += area
+
+		if area > largestArea {
+			largest, largestArea = i, area
+		}
+
+		for _, ring := range polygon {
+			m.PerimeterKm += ringLength(ring)
+
+			for _, p := range ring {
+				m.BoundingBox.MinLongitude = math.Min(m.BoundingBox.MinLongitude, p[0])
+				m.BoundingBox.MinLatitude = math.Min(m.BoundingBox.MinLatitude, p[1])
+				m.BoundingBox.MaxLongitude = math.Max(m.BoundingBox.MaxLongitude, p[0])
+				m.BoundingBox.MaxLatitude = math.Max(m.BoundingBox.MaxLatitude, p[1])
+			}
+		}
+	}
+
+	m.Centroid = centroid(polygons)
+	m.LabelPoint = poleOfInaccessibility(polygons[largest])
+
+	return m, nil
+}
+
+// Polygons returns the rings of a Polygon or MultiPolygon geometry, each one with at least 4 positions
+func (g Geometry) Polygons() ([][][][]float64, error) {
+	var polygons [][][][]float64
+
+	switch g.Type {
+	case GeometryPolygon:
+		if g.Coordinates == nil {
+			polygons = [][][][]float64{g.Polygon}
+			break
+		}
+
+		polygon, err := decodeRings(g.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+
+		polygons = [][][][]float64{polygon}
+	case GeometryMultiPolygon:
+		if g.Coordinates == nil {
+			polygons = g.MultiPolygon
+			break
+		}
+
+		for _, c := range g.Coordinates {
+			rings, ok := c.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid multipolygon coordinates")
+			}
+
+			polygon, err := decodeRings(rings)
+			if err != nil {
+				return nil, err
+			}
+
+			polygons = append(polygons, polygon)
+		}
+	default:
+		return nil, fmt.Errorf("geometry must be a Polygon or MultiPolygon, got %s", g.Type)
+	}
+
+	if len(polygons) == 0 {
+		return nil, fmt.Errorf("geometry has no polygons")
+	}
+
+	for _, polygon := range polygons {
+		if len(polygon) == 0 {
+			return nil, fmt.Errorf("polygon has no rings")
+		}
+
+		for _, ring := range polygon {
+			if len(ring) < 4 {
+				return nil, fmt.Errorf("polygon rings must have at least 4 positions")
+			}
+		}
+	}
+
+	return polygons, nil
+}
+
+// decodeRings is like encodePolygon but fails instead of panicking on malformed coordinates
+func decodeRings(co []interface{}) ([][][]float64, error) {
+	rings := make([][][]float64, 0, len(co))
+
+	for _, r := range co {
+		positions, ok := r.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid polygon coordinates")
+		}
+
+		ring := make([][]float64, 0, len(positions))
+
+		for _, p := range positions {
+			values, ok := p.([]interface{})
+			if !ok || len(values) < 2 {
+				return nil, fmt.Errorf("invalid polygon position")
+			}
+
+			lon, okLon := values[0].(float64)
+			lat, okLat := values[1].(float64)
+
+			if !okLon || !okLat {
+				return nil, fmt.Errorf("invalid polygon position")
+			}
+
+			ring = append(ring, []float64{lon, lat})
+		}
+
+		rings = append(rings, ring)
+	}
+
+	return rings, nil
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// polygonArea is the area of the outer ring minus the holes in km²
+func polygonArea(polygon [][][]float64) float64 {
+	area := ringArea(polygon[0])
+
+	for _, hole := range polygon[1:] {
+		area -= ringArea(hole)
+	}
+
+	return math.Max(area, 0)
+}
+
+// ringArea is the spherical area of a ring, see "Some Algorithms for Polygons on a Sphere" by Chamberlain and Duquette
+func ringArea(ring [][]float64) float64 {
+	total := 0.0
+
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+		total += radians(p2[0]-p1[0]) * (2 + math.Sin(radians(p1[1])) + math.Sin(radians(p2[1])))
+	}
+
+	return math.Abs(total * earthRadiusKm * earthRadiusKm / 2)
+}
+
+// ringLength is the haversine length of a ring in km
+func ringLength(ring [][]float64) float64 {
+	total := 0.0
+
+	for i := 1; i < len(ring); i++ {
+		total += haversine(ring[i-1], ring[i])
+	}
+
+	return total
+}
+
+// DistanceKm is the great circle distance between two points
+func DistanceKm(from, to Center) float64 {
+	return haversine([]float64{from.Longitude, from.Latitude}, []float64{to.Longitude, to.Latitude})
+}
+
+func haversine(p1, p2 []float64) float64 {
+	lat1, lat2 := radians(p1[1]), radians(p2[1])
+	dLat, dLon := lat2-lat1, radians(p2[0]-p1[0])
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// centroid is the area weighted centroid of the polygons in degrees, holes weight negatively
+func centroid(polygons [][][][]float64) Center {
+	var x, y, area float64
+
+	for _, polygon := range polygons {
+		for i, ring := range polygon {
+			a, cx, cy := ringCentroid(ring)
+
+			if i > 0 {
+				a = -a
+			}
+
+			x += cx * a
+			y += cy * a
+			area += a
+		}
+	}
+
+	if area == 0 {
+		// Degenerated polygons, fallback to the average of the outer ring positions
+		ring := polygons[0][0]
+
+		for _, p := range ring {
+			x += p[0]
+			y += p[1]
+		}
+
+		return Center{Longitude: x / float64(len(ring)), Latitude: y / float64(len(ring))}
+	}
+
+	return Center{Longitude: x / area, Latitude: y / area}
+}
+
+// ringCentroid returns the absolute planar area and the centroid of a ring
+func ringCentroid(ring [][]float64) (float64, float64, float64) {
+	var area, x, y float64
+
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+		f := p1[0]*p2[1] - p2[0]*p1[1]
+
+		area += f
+		x += (p1[0] + p2[0]) * f
+		y += (p1[1] + p2[1]) * f
+	}
+
+	if area == 0 {
+		return 0, 0, 0
+	}
+
+	return math.Abs(area / 2), x / (3 * area), y / (3 * area)
+}
+
+// poleOfInaccessibility finds the point of the polygon farthest from its edges with the polylabel algorithm by Mapbox.
+// Longitudes are scaled by the cosine of the latitude so distances are not stretched away from the equator.
+func poleOfInaccessibility(polygon [][][]float64) Center {
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+
+	for _, p := range polygon[0] {
+		minLat, maxLat = math.Min(minLat, p[1]), math.Max(maxLat, p[1])
+	}
+
+	scale := math.Max(math.Cos(radians((minLat+maxLat)/2)), 0.01)
+
+	projected := make([][][]float64, 0, len(polygon))
+
+	for _, ring := range polygon {
+		r := make([][]float64, 0, len(ring))
+
+		for _, p := range ring {
+			r = append(r, []float64{p[0] * scale, p[1]})
+		}
+
+		projected = append(projected, r)
+	}
+
+	x, y := polylabel(projected)
+
+	return Center{Longitude: x / scale, Latitude: y}
+}
+
+type labelCell struct {
+	x, y float64 // center
+	h    float64 // half size
+	d    float64 // distance from the center to the polygon
+	max  float64 // max distance to the polygon within the cell
+}
+
+func newLabelCell(x, y, h float64, polygon [][][]float64) *labelCell {
+	d := pointToPolygonDistance(x, y, polygon)
+
+	return &labelCell{x: x, y: y, h: h, d: d, max: d + h*math.Sqrt2}
+}
+
+// labelQueue is a max heap of cells by their potential distance
+type labelQueue []*labelCell
+
+func (q labelQueue) Len() int            { return len(q) }
+func (q labelQueue) Less(i, j int) bool  { return q[i].max > q[j].max }
+func (q labelQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *labelQueue) Push(x interface{}) { *q = append(*q, x.(*labelCell)) }
+
+func (q *labelQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return c
+}
+
+func polylabel(polygon [][][]float64) (float64, float64) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+
+	for _, p := range polygon[0] {
+		minX, minY = math.Min(minX, p[0]), math.Min(minY, p[1])
+		maxX, maxY = math.Max(maxX, p[0]), math.Max(maxY, p[1])
+	}
+
+	width, height := maxX-minX, maxY-minY
+	cellSize := math.Min(width, height)
+
+	if cellSize == 0 {
+		return minX, minY
+	}
+
+	precision := math.Max(width, height) / 1000
+	h := cellSize / 2
+
+	queue := &labelQueue{}
+
+	for x := minX; x < maxX; x += cellSize {
+		for y := minY; y < maxY; y += cellSize {
+			heap.Push(queue, newLabelCell(x+h, y+h, h, polygon))
+		}
+	}
+
+	_, cx, cy := ringCentroid(polygon[0])
+	best := newLabelCell(cx, cy, 0, polygon)
+
+	if bboxCell := newLabelCell(minX+width/2, minY+height/2, 0, polygon); bboxCell.d > best.d {
+		best = bboxCell
+	}
+
+	for queue.Len() > 0 {
+		cell := heap.Pop(queue).(*labelCell)
+
+		if cell.d > best.d {
+			best = cell
+		}
+
+		if cell.max-best.d <= precision {
+			continue
+		}
+
+		h = cell.h / 2
+		heap.Push(queue, newLabelCell(cell.x-h, cell.y-h, h, polygon))
+		heap.Push(queue, newLabelCell(cell.x+h, cell.y-h, h, polygon))
+		heap.Push(queue, newLabelCell(cell.x-h, cell.y+h, h, polygon))
+		heap.Push(queue, newLabelCell(cell.x+h, cell.y+h, h, polygon))
+	}
+
+	return best.x, best.y
+}
+
+// pointToPolygonDistance is the distance to the nearest edge, negative when the point is outside
+func pointToPolygonDistance(x, y float64, polygon [][][]float64) float64 {
+	inside := false
+	minDist := math.Inf(1)
+
+	for _, ring := range polygon {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+
+			if (a[1] > y) != (b[1] > y) && x < (b[0]-a[0])*(y-a[1])/(b[1]-a[1])+a[0] {
+				inside = !inside
+			}
+
+			minDist = math.Min(minDist, segmentDistance(x, y, a, b))
+		}
+	}
+
+	if inside {
+		return minDist
+	}
+
+	return -minDist
+}
+
+func segmentDistance(px, py float64, a, b []float64) float64 {
+	x, y := a[0], a[1]
+	dx, dy := b[0]-x, b[1]-y
+
+	if dx != 0 || dy != 0 {
+		t := ((px-x)*dx + (py-y)*dy) / (dx*dx + dy*dy)
+
+		if t > 1 {
+			x, y = b[0], b[1]
+		} else if t > 0 {
+			x += dx * t
+			y += dy * t
+		}
+	}
+
+	return math.Hypot(px-x, py-y)
+}
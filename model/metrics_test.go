@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func square(minX, minY, maxX, maxY float64) []interface{} {
+	return []interface{}{
+		[]interface{}{minX, minY},
+		[]interface{}{maxX, minY},
+		[]interface{}{maxX, maxY},
+		[]interface{}{minX, maxY},
+		[]interface{}{minX, minY},
+	}
+}
+
+func TestNewPolygonMetrics(t *testing.T) {
+	// Given
+	g := Geometry{Type: GeometryPolygon, Coordinates: []interface{}{square(0, 0, 1, 1)}}
+
+	// When
+	m, err := NewPolygonMetrics(g)
+
+	// Then
+	require.NoError(t, err)
+	assert.InDelta(t, 12364, m.AreaKm2, 10)
+	assert.InDelta(t, 444.8, m.PerimeterKm, 1)
+	assert.InDelta(t, 0.5, m.Centroid.Longitude, 1e-9)
+	assert.InDelta(t, 0.5, m.Centroid.Latitude, 1e-9)
+	assert.Equal(t, BoundingBox{MinLongitude: 0, MinLatitude: 0, MaxLongitude: 1, MaxLatitude: 1}, m.BoundingBox)
+	assert.InDelta(t, 0.5, m.LabelPoint.Longitude, 0.01)
+	assert.InDelta(t, 0.5, m.LabelPoint.Latitude, 0.01)
+}
+
+func TestNewPolygonMetricsLabelPointAvoidsHoles(t *testing.T) {
+	// Given
+	g := Geometry{Type: GeometryMultiPolygon, Coordinates: []interface{}{
+		[]interface{}{square(0, 0, 10, 10), square(2, 2, 8, 8)},
+		[]interface{}{square(20, 0, 21, 1)},
+	}}
+
+	// When
+	m, err := NewPolygonMetrics(g)
+
+	// Then
+	require.NoError(t, err)
+	assert.InDelta(t, (64*5+20.5)/65, m.Centroid.Longitude, 1e-9)
+	assert.False(t, m.LabelPoint.Longitude > 2 && m.LabelPoint.Longitude < 8 &&
+		m.LabelPoint.Latitude > 2 && m.LabelPoint.Latitude < 8, "label point inside the hole")
+	assert.True(t, m.LabelPoint.Longitude < 10, "label point outside the largest polygon")
+}
+
+func TestNewPolygonMetricsInvalidGeometry(t *testing.T) {
+	_, err := NewPolygonMetrics(*NewPointGeometry([]interface{}{1.0, 2.0}))
+	assert.Error(t, err)
+
+	_, err = NewPolygonMetrics(Geometry{Type: GeometryPolygon, Coordinates: []interface{}{"invalid"}})
+	assert.Error(t, err)
+}
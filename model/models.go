@@ -2,6 +2,7 @@ package model
 
 import (
 	"encoding/json"
+	"strings"
 
 	"gopkg.in/mgo.v2/bson"
 )
@@ -333,9 +334,44 @@ const (
 	RegionTypeNeighborhood      RegionType = "neighborhood"
 	RegionTypeTrainStation      RegionType = "train_station"
 	RegionTypeAccommodation     RegionType = "accommodation"
+	RegionTypeCustomArea        RegionType = "custom_area"
 	RegionTypeGeo               RegionType = "geo_coordinates"
 )
 
+// regionTypes are the curated region types stored as collections
+var regionTypes = []RegionType{
+	RegionTypeCity,
+	RegionTypeCountry,
+	RegionTypeContinent,
+	RegionTypeHighLevelRegion,
+	RegionTypeMetroStation,
+	RegionTypeProvinceState,
+	RegionTypeMultiCityVicinity,
+	RegionTypePOI,
+	RegionTypeNeighborhood,
+	RegionTypeTrainStation,
+}
+
+// RegionTypes returns the curated region types stored as collections
+func RegionTypes() []RegionType {
+	return append([]RegionType(nil), regionTypes...)
+}
+
+// IsValid returns if the region type is one of the known region collections, custom areas included
+func (t RegionType) IsValid() bool {
+	if t == RegionTypeCustomArea {
+		return true
+	}
+
+	for _, rt := range regionTypes {
+		if t == rt {
+			return true
+		}
+	}
+
+	return false
+}
+
 // BaseRegion is a basic region data
 type BaseRegion struct {
 	ID    bson.ObjectId `json:"_id" bson:"_id"`
@@ -346,17 +382,33 @@ type BaseRegion struct {
 // Region is the new version of geo entity
 type Region struct {
 	BaseRegion  `bson:",inline"`
-	Name        map[Language]string `json:"name" bson:"name"`
-	CountryCode string              `json:"country_code,omitempty" bson:"country_code,omitempty"`
-	Center      Center              `json:"center" bson:"coordinates"`
-	Ancestors   []Ancestor          `json:"ancestors" bson:"ancestors"`
-	Descendants Descendants         `json:"descendants" bson:"descendants"`
+	Name        map[Language]string   `json:"name" bson:"name"`
+	Aliases     map[Language][]string `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	AliasNames  []string              `json:"-" bson:"alias_names,omitempty"`
+	CountryCode string                `json:"country_code,omitempty" bson:"country_code,omitempty"`
+	Timezone    string                `json:"timezone,omitempty" bson:"timezone,omitempty"`
+	Center      Center                `json:"center" bson:"coordinates"`
+	Ancestors   []Ancestor            `json:"ancestors" bson:"ancestors"`
+	Descendants Descendants           `json:"descendants" bson:"descendants"`
 }
 
 // GeoRegion is a baseRegion + a geometry
 type GeoRegion struct {
 	BaseRegion `bson:",inline"`
 	Geometry   Geometry `json:"geometry" bson:"bounding_polygon"`
+	Geohash    string   `json:"geohash,omitempty" bson:"geohash,omitempty"`
+}
+
+// RegionExport is a region with the polygons that share its geo_id, as read by the exports
+type RegionExport struct {
+	Region   `bson:",inline"`
+	Polygons []GeoRegion `json:"-" bson:"polygons,omitempty"`
+}
+
+// NearbyRegion is a GeoRegion with its distance to a point
+type NearbyRegion struct {
+	GeoRegion  `bson:",inline"`
+	DistanceKm float64 `json:"distance_km" bson:"distance_km"`
 }
 
 // Ancestor reprecents a container/father region
@@ -383,12 +435,60 @@ type Descendants struct {
 type AirportV2 struct {
 	ID          bson.ObjectId       `json:"id" bson:"_id"`
 	IataCode    string              `json:"iata_code" bson:"iata"`
+	IcaoCode    string              `json:"icao_code,omitempty" bson:"icao,omitempty"`
 	Name        map[Language]string `json:"name" bson:"fullname"`
 	CountryCode string              `json:"country_code" bson:"countrycode"`
+	Timezone    string              `json:"timezone,omitempty" bson:"timezone,omitempty"`
 	Coordinates Coordinates         `json:"coordinates" bson:"coordinates"`
+	Geohash     string              `json:"geohash,omitempty" bson:"geohash,omitempty"`
 	Region      AirportRegion       `json:"region" bson:"region"`
 }
 
+// MetroArea is an IATA metropolitan area code (BUE, LON) grouping the airports that serve a city
+type MetroArea struct {
+	ID          bson.ObjectId       `json:"-" bson:"_id"`
+	Code        string              `json:"code" bson:"code"`
+	Name        map[Language]string `json:"name" bson:"name"`
+	CountryCode string              `json:"country_code" bson:"country_code"`
+	City        Ancestor            `json:"city" bson:"city"`
+	Airports    []string            `json:"airports" bson:"airports"`
+}
+
+// Normalize uppercases the codes of the metro area and drops the repeated airports, the city type defaults to city
+func (m *MetroArea) Normalize() {
+	m.Code = strings.ToUpper(strings.TrimSpace(m.Code))
+	m.CountryCode = strings.ToUpper(strings.TrimSpace(m.CountryCode))
+
+	if m.City.Type == "" {
+		m.City.Type = RegionTypeCity
+	}
+
+	airports := make([]string, 0, len(m.Airports))
+	seen := map[string]bool{}
+
+	for _, a := range m.Airports {
+		a = strings.ToUpper(strings.TrimSpace(a))
+
+		if !seen[a] {
+			seen[a] = true
+			airports = append(airports, a)
+		}
+	}
+
+	m.Airports = airports
+}
+
+// Localize implements Localizable
+func (m MetroArea) Localize(chain []Language) interface{} {
+	return LocalizedMetroArea{MetroArea: m, Name: LocalizedName(m.Name, chain)}
+}
+
+// LocalizedMetroArea is a MetroArea with its name in a single language
+type LocalizedMetroArea struct {
+	MetroArea
+	Name string `json:"name"`
+}
+
 // AirportRegion represents a region of an airport
 type AirportRegion struct {
 	ID   string              `json:"id" bson:"id"`
@@ -17,6 +17,24 @@ const (
 	GeoEntityTypeAccommodation GeoEntityType = "ACCOMMODATION"
 )
 
+// RegionType returns the V2 region type equivalent to the GeoEntity type, or an empty one if there is none
+func (t GeoEntityType) RegionType() RegionType {
+	switch t {
+	case GeoEntityTypeCountry:
+		return RegionTypeCountry
+	case GeoEntityTypeCity:
+		return RegionTypeCity
+	case GeoEntityTypeState:
+		return RegionTypeProvinceState
+	case GeoEntityTypeNeighbourhood:
+		return RegionTypeNeighborhood
+	case GeoEntityTypeAccommodation:
+		return RegionTypeAccommodation
+	}
+
+	return ""
+}
+
 // BaseEntity base fields for entity
 type BaseEntity struct {
 	ID   bson.ObjectId       `json:"id" bson:"_id"`
@@ -0,0 +1,263 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Projection selects the fields of a response using their JSON paths, e.g. name.es,center,ancestors.
+// Fields prefixed with - are excluded instead, e.g. -descendants. Both kinds cannot be mixed.
+type Projection struct {
+	Fields  []string
+	Exclude bool
+}
+
+// ParseProjection parses a comma separated list of fields, it returns nil when there are none
+func ParseProjection(fields string) (*Projection, error) {
+	p := Projection{}
+
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+
+		exclude := strings.HasPrefix(f, "-")
+		if len(p.Fields) > 0 && exclude != p.Exclude {
+			return nil, fmt.Errorf("[fields] cannot mix included and excluded fields")
+		}
+
+		p.Exclude = exclude
+		p.Fields = append(p.Fields, strings.TrimPrefix(f, "-"))
+	}
+
+	if len(p.Fields) == 0 {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+// BSON translates the projection to a mongo projection using the json and bson tags of the target type.
+// The keep fields are always included, excluding them has no effect.
+func (p *Projection) BSON(target interface{}, keep ...string) (bson.M, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(target)
+
+	value := 1
+	fields := p.Fields
+
+	if p.Exclude {
+		value = 0
+		fields = p.excluded(keep)
+	} else {
+		fields = append(append([]string{}, fields...), keep...)
+	}
+
+	result := bson.M{}
+
+	for _, f := range fields {
+		path, err := bsonPath(t, strings.Split(f, "."))
+		if err != nil {
+			return nil, err
+		}
+
+		result[path] = value
+	}
+
+	return result, nil
+}
+
+// Apply removes from the JSON representation of the data the fields not selected by the projection, the keep fields stay
+func (p *Projection) Apply(data interface{}, keep ...string) (interface{}, error) {
+	if p == nil {
+		return data, nil
+	}
+
+	blob, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply fields. %w", err)
+	}
+
+	var doc interface{}
+
+	if err = json.Unmarshal(blob, &doc); err != nil {
+		return nil, fmt.Errorf("failed to apply fields. %w", err)
+	}
+
+	if p.Exclude {
+		for _, f := range p.excluded(keep) {
+			removePath(doc, strings.Split(f, "."))
+		}
+
+		return doc, nil
+	}
+
+	tree := fieldTree{}
+	for _, f := range append(append([]string{}, p.Fields...), keep...) {
+		tree.add(strings.Split(f, "."))
+	}
+
+	return tree.keep(doc), nil
+}
+
+// excluded returns the excluded fields but the keep fields and the ones inside them
+func (p *Projection) excluded(keep []string) []string {
+	fields := make([]string, 0, len(p.Fields))
+
+	for _, f := range p.Fields {
+		kept := false
+
+		for _, k := range keep {
+			if f == k || strings.HasPrefix(f, k+".") {
+				kept = true
+			}
+		}
+
+		if !kept {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// fieldTree is a set of JSON paths, a leaf keeps the whole value
+type fieldTree map[string]fieldTree
+
+func (t fieldTree) add(path []string) {
+	sub, ok := t[path[0]]
+
+	if len(path) == 1 {
+		t[path[0]] = nil
+
+		return
+	}
+
+	if ok && sub == nil {
+		return
+	}
+
+	if !ok {
+		sub = fieldTree{}
+		t[path[0]] = sub
+	}
+
+	sub.add(path[1:])
+}
+
+func (t fieldTree) keep(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+
+		for k, sub := range t {
+			value, ok := v[k]
+			if !ok {
+				continue
+			}
+
+			if sub == nil {
+				result[k] = value
+			} else {
+				result[k] = sub.keep(value)
+			}
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+
+		for _, e := range v {
+			result = append(result, t.keep(e))
+		}
+
+		return result
+	}
+
+	return doc
+}
+
+func removePath(doc interface{}, path []string) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+
+			return
+		}
+
+		removePath(v[path[0]], path[1:])
+	case []interface{}:
+		for _, e := range v {
+			removePath(e, path)
+		}
+	}
+}
+
+// bsonPath finds the bson path of a JSON path walking the struct tags of the type
+func bsonPath(t reflect.Type, path []string) (string, error) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Map {
+		// Map keys, like the languages of a name, are the same in both representations
+		return strings.Join(path, "."), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("[fields] %s is not a valid field", strings.Join(path, "."))
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		jsonName := tagName(f.Tag.Get("json"))
+		bsonName := tagName(f.Tag.Get("bson"))
+
+		if f.Anonymous && jsonName == "" {
+			if p, err := bsonPath(f.Type, path); err == nil {
+				return p, nil
+			}
+
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+
+		if jsonName != path[0] || jsonName == "-" || bsonName == "-" {
+			continue
+		}
+
+		if bsonName == "" {
+			bsonName = strings.ToLower(f.Name)
+		}
+
+		if len(path) == 1 {
+			return bsonName, nil
+		}
+
+		rest, err := bsonPath(f.Type, path[1:])
+		if err != nil {
+			return "", fmt.Errorf("[fields] %s is not a valid field", strings.Join(path, "."))
+		}
+
+		return bsonName + "." + rest, nil
+	}
+
+	return "", fmt.Errorf("[fields] %s is not a valid field", strings.Join(path, "."))
+}
+
+func tagName(tag string) string {
+	return strings.Split(tag, ",")[0]
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestProjectionBSON(t *testing.T) {
+	// Given
+	p, err := ParseProjection("name.es,center.latitude,ancestors.id,descendants.neighborhoods")
+	require.NoError(t, err)
+
+	// When
+	fields, err := p.BSON(Region{}, "id")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{
+		"name.es":                     1,
+		"coordinates.center_latitude": 1,
+		"ancestors.geo_id":            1,
+		"descendants.neighborhood":    1,
+		"geo_id":                      1,
+	}, fields)
+}
+
+func TestProjectionBSONExclude(t *testing.T) {
+	// Given
+	p, err := ParseProjection("-descendants")
+	require.NoError(t, err)
+
+	// When
+	fields, err := p.BSON(AirportV2{}, "iata_code")
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, fields)
+
+	fields, err = p.BSON(Region{}, "id")
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"descendants": 0}, fields)
+}
+
+func TestProjectionInvalid(t *testing.T) {
+	_, err := ParseProjection("name,-descendants")
+	assert.Error(t, err)
+
+	p, err := ParseProjection("population")
+	require.NoError(t, err)
+
+	_, err = p.BSON(Region{})
+	assert.Error(t, err)
+}
+
+func TestProjectionApply(t *testing.T) {
+	// Given
+	regions := []Region{{
+		BaseRegion:  BaseRegion{GeoID: "6139184"},
+		Name:        map[Language]string{"es": "Palermo", "en": "Palermo"},
+		CountryCode: "AR",
+		Descendants: Descendants{Accommodations: []string{"1", "2"}},
+	}}
+	p, err := ParseProjection("name.es,country_code")
+	require.NoError(t, err)
+
+	// When
+	result, err := p.Apply(regions, "id")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":           "6139184",
+		"name":         map[string]interface{}{"es": "Palermo"},
+		"country_code": "AR",
+	}}, result)
+}
+
+func TestProjectionExcludeKeepsKeyFields(t *testing.T) {
+	// Given
+	p, err := ParseProjection("-id,-descendants,-name.en")
+	require.NoError(t, err)
+
+	region := Region{
+		BaseRegion:  BaseRegion{GeoID: "6139184"},
+		Name:        map[Language]string{"es": "Palermo", "en": "Palermo"},
+		Descendants: Descendants{Accommodations: []string{"1"}},
+	}
+
+	// When
+	fields, err := p.BSON(Region{}, "id")
+	require.NoError(t, err)
+
+	result, err := p.Apply(region, "id")
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, bson.M{"descendants": 0, "name.en": 0}, fields)
+	assert.Equal(t, "6139184", result.(map[string]interface{})["id"])
+	assert.NotContains(t, result, "descendants")
+}
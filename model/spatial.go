@@ -0,0 +1,122 @@
+package model
+
+import "math"
+
+// PolygonsContain returns if the point is inside any of the polygons, the edges are inside and the holes outside
+func PolygonsContain(polygons [][][][]float64, longitude, latitude float64) bool {
+	for _, polygon := range polygons {
+		if pointToPolygonDistance(longitude, latitude, polygon) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PolygonsDistanceKm is the great circle distance from the point to the nearest edge of the polygons, 0 when it is inside.
+// The nearest point of each edge is found with longitudes scaled by the cosine of the latitude.
+func PolygonsDistanceKm(polygons [][][][]float64, longitude, latitude float64) float64 {
+	if PolygonsContain(polygons, longitude, latitude) {
+		return 0
+	}
+
+	scale := math.Max(math.Cos(radians(latitude)), 0.01)
+	point := []float64{longitude, latitude}
+	best := math.Inf(1)
+
+	for _, polygon := range polygons {
+		for _, ring := range polygon {
+			for i := 1; i < len(ring); i++ {
+				a, b := ring[i-1], ring[i]
+				ax, bx, px := a[0]*scale, b[0]*scale, longitude*scale
+				dx, dy := bx-ax, b[1]-a[1]
+
+				t := 0.0
+				if dx != 0 || dy != 0 {
+					t = math.Max(0, math.Min(1, ((px-ax)*dx+(latitude-a[1])*dy)/(dx*dx+dy*dy)))
+				}
+
+				nearest := []float64{a[0] + (b[0]-a[0])*t, a[1] + dy*t}
+				best = math.Min(best, haversine(point, nearest))
+			}
+		}
+	}
+
+	return best
+}
+
+// PolygonsIntersect returns if two sets of polygons share any point, one inside the other included
+func PolygonsIntersect(a, b [][][][]float64) bool {
+	for _, pa := range a {
+		for _, pb := range b {
+			if polygonsIntersect(pa, pb) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func polygonsIntersect(a, b [][][]float64) bool {
+	if !ringBounds(a[0]).overlaps(ringBounds(b[0])) {
+		return false
+	}
+
+	if pointToPolygonDistance(b[0][0][0], b[0][0][1], a) >= 0 || pointToPolygonDistance(a[0][0][0], a[0][0][1], b) >= 0 {
+		return true
+	}
+
+	for _, ra := range a {
+		for i := 1; i < len(ra); i++ {
+			for _, rb := range b {
+				for j := 1; j < len(rb); j++ {
+					if segmentsIntersect(ra[i-1], ra[i], rb[j-1], rb[j]) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+type bounds struct {
+	minX, minY, maxX, maxY float64
+}
+
+func ringBounds(ring [][]float64) bounds {
+	b := bounds{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+
+	for _, p := range ring {
+		b.minX, b.minY = math.Min(b.minX, p[0]), math.Min(b.minY, p[1])
+		b.maxX, b.maxY = math.Max(b.maxX, p[0]), math.Max(b.maxY, p[1])
+	}
+
+	return b
+}
+
+func (b bounds) overlaps(o bounds) bool {
+	return b.minX <= o.maxX && o.minX <= b.maxX && b.minY <= o.maxY && o.minY <= b.maxY
+}
+
+// segmentsIntersect returns if the segments pq and rs cross or touch
+func segmentsIntersect(p, q, r, s []float64) bool {
+	d1 := cross(r[0], r[1], s[0], s[1], p[0], p[1])
+	d2 := cross(r[0], r[1], s[0], s[1], q[0], q[1])
+	d3 := cross(p[0], p[1], q[0], q[1], r[0], r[1])
+	d4 := cross(p[0], p[1], q[0], q[1], s[0], s[1])
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	onSegment := func(a, b, c []float64) bool {
+		return math.Min(a[0], b[0]) <= c[0] && c[0] <= math.Max(a[0], b[0]) &&
+			math.Min(a[1], b[1]) <= c[1] && c[1] <= math.Max(a[1], b[1])
+	}
+
+	return (d1 == 0 && onSegment(r, s, p)) || (d2 == 0 && onSegment(r, s, q)) ||
+		(d3 == 0 && onSegment(p, q, r)) || (d4 == 0 && onSegment(p, q, s))
+}
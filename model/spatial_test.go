@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func squarePolygons(minX, minY, maxX, maxY float64) [][][][]float64 {
+	return [][][][]float64{{{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY}}}}
+}
+
+func TestPolygonsContain(t *testing.T) {
+	withHole := [][][][]float64{{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}},
+	}}
+
+	assert.True(t, PolygonsContain(withHole, 1, 1))
+	assert.True(t, PolygonsContain(withHole, 0, 5))
+	assert.False(t, PolygonsContain(withHole, 5, 5))
+	assert.False(t, PolygonsContain(withHole, 11, 5))
+}
+
+func TestPolygonsDistanceKm(t *testing.T) {
+	square := squarePolygons(0, 0, 1, 1)
+
+	assert.Zero(t, PolygonsDistanceKm(square, 0.5, 0.5))
+	assert.InDelta(t, 111.2, PolygonsDistanceKm(square, 2, 0.5), 0.5)
+	assert.InDelta(t, DistanceKm(Center{Longitude: 1, Latitude: 1}, Center{Longitude: 2, Latitude: 2}),
+		PolygonsDistanceKm(square, 2, 2), 0.5)
+}
+
+func TestPolygonsIntersect(t *testing.T) {
+	square := squarePolygons(0, 0, 10, 10)
+
+	assert.True(t, PolygonsIntersect(square, squarePolygons(5, 5, 15, 15)), "overlapping")
+	assert.True(t, PolygonsIntersect(square, squarePolygons(2, 2, 3, 3)), "inside")
+	assert.True(t, PolygonsIntersect(squarePolygons(2, 2, 3, 3), square), "containing")
+	assert.True(t, PolygonsIntersect(square, squarePolygons(10, 0, 20, 10)), "touching")
+	assert.True(t, PolygonsIntersect(square, [][][][]float64{{{{-1, 4}, {11, 4}, {11, 6}, {-1, 6}, {-1, 4}}}}), "crossing")
+	assert.False(t, PolygonsIntersect(square, squarePolygons(11, 11, 12, 12)), "apart")
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	// The image does not ship the IANA database, it is embedded in the binary
+	_ "time/tzdata"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// TimezoneRegion is the polygon of an IANA timezone
+type TimezoneRegion struct {
+	ID       bson.ObjectId `json:"-" bson:"_id,omitempty"`
+	Zone     string        `json:"zone" bson:"tzid"`
+	Geometry Geometry      `json:"geometry" bson:"geometry"`
+}
+
+// Timezone is the state of an IANA timezone at a given date
+type Timezone struct {
+	Zone      string    `json:"zone"`
+	UTCOffset int       `json:"utc_offset"`
+	Offset    string    `json:"offset"`
+	DST       bool      `json:"dst"`
+	Date      time.Time `json:"date"`
+}
+
+// NewTimezone resolves the offset and daylight saving status of the zone at the date
+func NewTimezone(zone string, date time.Time) (*Timezone, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s. %w", zone, err)
+	}
+
+	local := date.In(loc)
+	_, offset := local.Zone()
+
+	// The standard offset is the lowest of the year, zones without DST have the same offset in January and July
+	_, jan := time.Date(local.Year(), time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(local.Year(), time.July, 1, 0, 0, 0, 0, loc).Zone()
+
+	standard := jan
+	if jul < standard {
+		standard = jul
+	}
+
+	return &Timezone{
+		Zone:      zone,
+		UTCOffset: offset,
+		Offset:    local.Format("-07:00"),
+		DST:       jan != jul && offset != standard,
+		Date:      local,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimezoneWithDST(t *testing.T) {
+	// Given
+	summer := time.Date(2021, time.July, 15, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	// When
+	tzSummer, err := NewTimezone("Europe/Madrid", summer)
+	require.NoError(t, err)
+
+	tzWinter, err := NewTimezone("Europe/Madrid", winter)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 7200, tzSummer.UTCOffset)
+	assert.True(t, tzSummer.DST)
+	assert.Equal(t, "+01:00", tzWinter.Offset)
+	assert.False(t, tzWinter.DST)
+}
+
+func TestNewTimezoneWithoutDST(t *testing.T) {
+	// When
+	tz, err := NewTimezone("America/Argentina/Buenos_Aires", time.Date(2021, time.July, 15, 12, 0, 0, 0, time.UTC))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "-03:00", tz.Offset)
+	assert.False(t, tz.DST)
+}
+
+func TestNewTimezoneUnknownZone(t *testing.T) {
+	_, err := NewTimezone("Mars/Olympus_Mons", time.Now())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+)
+
+// regionRanks orders the curated region types from the coarsest, an ancestor ranks below its descendants.
+// The rank of the high level regions is not used, see IsCoarserThan.
+var regionRanks = map[RegionType]int{
+	RegionTypeContinent:         0,
+	RegionTypeCountry:           1,
+	RegionTypeHighLevelRegion:   2,
+	RegionTypeProvinceState:     3,
+	RegionTypeMultiCityVicinity: 4,
+	RegionTypeCity:              5,
+	RegionTypeNeighborhood:      6,
+	RegionTypePOI:               7,
+	RegionTypeTrainStation:      7,
+	RegionTypeMetroStation:      7,
+}
+
+// IsCoarserThan returns if regions of the type can be ancestors of regions of the other type.
+// High level regions group regions of any level, e.g. countries of a continent or provinces of a country,
+// so they can be ancestors and descendants of any type but the continents, that are always the coarsest.
+func (t RegionType) IsCoarserThan(other RegionType) bool {
+	rank, ok := regionRanks[t]
+	otherRank, otherOk := regionRanks[other]
+
+	if !ok || !otherOk {
+		return false
+	}
+
+	if t == RegionTypeHighLevelRegion || other == RegionTypeHighLevelRegion {
+		return other != RegionTypeContinent
+	}
+
+	return rank < otherRank
+}
+
+// Validate checks the fields of a region before it is saved, the errors are reported per field.
+// The existence of the ancestors is checked against the repository by the caller.
+func (r *Region) Validate() error {
+	var fields []*pkgErrors.Error
+
+	if r.GeoID == "" {
+		fields = append(fields, required("id"))
+	}
+
+	if _, ok := regionRanks[r.Type]; !ok {
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", r.Type).WithField("type"))
+	}
+
+	if len(r.Name) == 0 {
+		fields = append(fields, required("name"))
+	}
+
+	fields = append(fields, languageErrors("name", nameLanguages(r.Name))...)
+	fields = append(fields, languageErrors("aliases", aliasLanguages(r.Aliases))...)
+
+	if r.CountryCode != "" && !IsISOCountryCode(r.CountryCode) {
+		fields = append(fields, invalidCountryCode(r.CountryCode))
+	}
+
+	if r.Center != (Center{}) && !IsValidCoordinate(r.Center.Latitude, r.Center.Longitude) {
+		fields = append(fields, invalidCoordinates("center", r.Center.Latitude, r.Center.Longitude))
+	}
+
+	for i, a := range r.Ancestors {
+		field := fmt.Sprintf("ancestors[%d]", i)
+
+		if a.ID == "" {
+			fields = append(fields, required(field+".id"))
+		}
+
+		if _, ok := regionRanks[a.Type]; !ok {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", a.Type).WithField(field+".type"))
+		} else if _, ok := regionRanks[r.Type]; ok && !a.Type.IsCoarserThan(r.Type) {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidAncestor, "a %s can not be an ancestor of a %s", a.Type, r.Type).WithField(field))
+		}
+	}
+
+	return pkgErrors.Validation(fields)
+}
+
+// Validate checks the fields of an airport before it is saved, the errors are reported per field
+func (a *AirportV2) Validate() error {
+	var fields []*pkgErrors.Error
+
+	if !IsIATACode(a.IataCode) {
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidIATACode, "%q must be three uppercase letters", a.IataCode).WithField("iata_code"))
+	}
+
+	if a.IcaoCode != "" && !IsICAOCode(a.IcaoCode) {
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidICAOCode, "%q must be four uppercase letters", a.IcaoCode).WithField("icao_code"))
+	}
+
+	if len(a.Name) == 0 {
+		fields = append(fields, required("name"))
+	}
+
+	fields = append(fields, languageErrors("name", nameLanguages(a.Name))...)
+
+	if !IsISOCountryCode(a.CountryCode) {
+		fields = append(fields, invalidCountryCode(a.CountryCode))
+	}
+
+	if !IsValidCoordinate(a.Coordinates.Latitude, a.Coordinates.Longitude) {
+		fields = append(fields, invalidCoordinates("coordinates", a.Coordinates.Latitude, a.Coordinates.Longitude))
+	}
+
+	if a.Region.Type != "" {
+		if _, ok := regionRanks[RegionType(a.Region.Type)]; !ok {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", a.Region.Type).WithField("region.type"))
+		}
+	}
+
+	return pkgErrors.Validation(fields)
+}
+
+// Validate checks the codes, names and city of a normalized metro area
+func (m *MetroArea) Validate() error {
+	var fields []*pkgErrors.Error
+
+	if !IsIATACode(m.Code) {
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidIATACode, "%q must be three uppercase letters", m.Code).WithField("code"))
+	}
+
+	if len(m.Name) == 0 {
+		fields = append(fields, required("name"))
+	}
+
+	fields = append(fields, languageErrors("name", nameLanguages(m.Name))...)
+
+	if !IsISOCountryCode(m.CountryCode) {
+		fields = append(fields, invalidCountryCode(m.CountryCode))
+	}
+
+	if m.City.ID == "" {
+		fields = append(fields, required("city.id"))
+	}
+
+	if m.City.Type != RegionTypeCity {
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a city", m.City.Type).WithField("city.type"))
+	}
+
+	if len(m.Airports) == 0 {
+		fields = append(fields, required("airports"))
+	}
+
+	for i, a := range m.Airports {
+		if !IsIATACode(a) {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidIATACode, "%q must be three uppercase letters", a).WithField(fmt.Sprintf("airports[%d]", i)))
+		}
+	}
+
+	return pkgErrors.Validation(fields)
+}
+
+// ValidateAliases checks the languages of a normalized aliases payload, it needs at least one alias
+func ValidateAliases(aliases map[Language][]string) error {
+	if len(aliases) == 0 {
+		return pkgErrors.Validation([]*pkgErrors.Error{required("aliases")})
+	}
+
+	return pkgErrors.Validation(languageErrors("aliases", aliasLanguages(aliases)))
+}
+
+func required(field string) *pkgErrors.Error {
+	return pkgErrors.New(pkgErrors.CodeMissingParameter, "is required").WithField(field)
+}
+
+func invalidCountryCode(code string) *pkgErrors.Error {
+	return pkgErrors.New(pkgErrors.CodeInvalidCountryCode, "%q is not an ISO 3166-1 alpha-2 code", code).WithField("country_code")
+}
+
+func invalidCoordinates(field string, latitude, longitude float64) *pkgErrors.Error {
+	return pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "%v,%v must be a latitude between -90 and 90 and a longitude between -180 and 180", latitude, longitude).
+		WithField(field)
+}
+
+// languageErrors reports the languages that are not ISO 639-1, sorted so that the errors are stable
+func languageErrors(field string, languages []Language) []*pkgErrors.Error {
+	sort.Slice(languages, func(i, j int) bool { return languages[i] < languages[j] })
+
+	var fields []*pkgErrors.Error
+
+	for _, l := range languages {
+		if !IsISOLanguage(l) {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidLanguage, "%q is not an ISO 639-1 language", l).WithField(field+"."+string(l)))
+		}
+	}
+
+	return fields
+}
+
+func nameLanguages(names map[Language]string) []Language {
+	languages := make([]Language, 0, len(names))
+
+	for l := range names {
+		languages = append(languages, l)
+	}
+
+	return languages
+}
+
+func aliasLanguages(aliases map[Language][]string) []Language {
+	languages := make([]Language, 0, len(aliases))
+
+	for l := range aliases {
+		languages = append(languages, l)
+	}
+
+	return languages
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCity() Region {
+	return Region{
+		BaseRegion:  BaseRegion{GeoID: "2", Type: RegionTypeCity},
+		Name:        map[Language]string{"es": "Buenos Aires", "pt-BR": "Buenos Aires"},
+		Aliases:     map[Language][]string{"en": {"BA"}},
+		CountryCode: "AR",
+		Center:      Center{Latitude: -34.6, Longitude: -58.38},
+		Ancestors:   []Ancestor{{ID: "178", Type: RegionTypeCountry}, {ID: "6", Type: RegionTypeContinent}},
+	}
+}
+
+func fieldCodes(t *testing.T, err error) map[string]pkgErrors.Code {
+	var e *pkgErrors.Error
+
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, pkgErrors.CodeInvalidPayload, e.Code)
+
+	codes := map[string]pkgErrors.Code{}
+	for _, f := range e.Fields {
+		codes[f.Field] = f.Code
+	}
+
+	return codes
+}
+
+func TestRegionValidate(t *testing.T) {
+	region := validCity()
+
+	assert.NoError(t, region.Validate())
+
+	region.Type = "citty"
+	region.Name = map[Language]string{"spanish": "Buenos Aires"}
+	region.CountryCode = "XX"
+	region.Center = Center{Latitude: 91, Longitude: -58.38}
+	region.Ancestors = append(region.Ancestors, Ancestor{Type: RegionTypeCountry})
+
+	assert.Equal(t, map[string]pkgErrors.Code{
+		"type":            pkgErrors.CodeInvalidRegionType,
+		"name.spanish":    pkgErrors.CodeInvalidLanguage,
+		"country_code":    pkgErrors.CodeInvalidCountryCode,
+		"center":          pkgErrors.CodeInvalidCoordinates,
+		"ancestors[2].id": pkgErrors.CodeMissingParameter,
+	}, fieldCodes(t, region.Validate()))
+}
+
+func TestRegionValidateAncestors(t *testing.T) {
+	region := validCity()
+	region.Ancestors = []Ancestor{{ID: "1", Type: RegionTypeNeighborhood}, {ID: "3", Type: RegionTypeCity}, {ID: "4", Type: "galaxy"}}
+
+	assert.Equal(t, map[string]pkgErrors.Code{
+		"ancestors[0]":      pkgErrors.CodeInvalidAncestor,
+		"ancestors[1]":      pkgErrors.CodeInvalidAncestor,
+		"ancestors[2].type": pkgErrors.CodeInvalidRegionType,
+	}, fieldCodes(t, region.Validate()))
+}
+
+func TestRegionValidateHighLevelRegionAncestor(t *testing.T) {
+	region := validCity()
+	region.Type = RegionTypeCountry
+	region.Ancestors = []Ancestor{{ID: "10", Type: RegionTypeHighLevelRegion}, {ID: "6", Type: RegionTypeContinent}}
+
+	assert.NoError(t, region.Validate())
+
+	region.Type = RegionTypeProvinceState
+
+	assert.NoError(t, region.Validate())
+
+	region.Type = RegionTypeHighLevelRegion
+	region.Ancestors = []Ancestor{{ID: "178", Type: RegionTypeCountry}}
+
+	assert.NoError(t, region.Validate())
+
+	region.Type = RegionTypeContinent
+	region.Ancestors = []Ancestor{{ID: "10", Type: RegionTypeHighLevelRegion}}
+
+	assert.Equal(t, map[string]pkgErrors.Code{"ancestors[0]": pkgErrors.CodeInvalidAncestor}, fieldCodes(t, region.Validate()))
+}
+
+func TestRegionValidateCustomArea(t *testing.T) {
+	region := validCity()
+	region.Type = RegionTypeCustomArea
+
+	assert.Equal(t, pkgErrors.CodeInvalidRegionType, fieldCodes(t, region.Validate())["type"])
+}
+
+func TestAirportValidate(t *testing.T) {
+	airport := AirportV2{
+		IataCode:    "EZE",
+		IcaoCode:    "SAEZ",
+		Name:        map[Language]string{"es": "Ezeiza"},
+		CountryCode: "AR",
+		Coordinates: Coordinates{Latitude: -34.82, Longitude: -58.53},
+	}
+
+	assert.NoError(t, airport.Validate())
+
+	airport.IataCode = "eze"
+	airport.IcaoCode = "SAE"
+	airport.Name = nil
+	airport.CountryCode = ""
+	airport.Coordinates = Coordinates{}
+	airport.Region = AirportRegion{ID: "2", Type: "citty"}
+
+	err := airport.Validate()
+
+	assert.Equal(t, map[string]pkgErrors.Code{
+		"iata_code":    pkgErrors.CodeInvalidIATACode,
+		"icao_code":    pkgErrors.CodeInvalidICAOCode,
+		"name":         pkgErrors.CodeMissingParameter,
+		"country_code": pkgErrors.CodeInvalidCountryCode,
+		"coordinates":  pkgErrors.CodeInvalidCoordinates,
+		"region.type":  pkgErrors.CodeInvalidRegionType,
+	}, fieldCodes(t, err))
+	assert.Contains(t, err.Error(), `[iata_code] "eze" must be three uppercase letters`)
+}
+
+func TestMetroAreaValidate(t *testing.T) {
+	metro := MetroArea{
+		Code:        " bue",
+		Name:        map[Language]string{"es": "Buenos Aires"},
+		CountryCode: "ar",
+		City:        Ancestor{ID: "2"},
+		Airports:    []string{"eze", "AEP", "EZE "},
+	}
+
+	metro.Normalize()
+
+	assert.Equal(t, "BUE", metro.Code)
+	assert.Equal(t, "AR", metro.CountryCode)
+	assert.Equal(t, RegionTypeCity, metro.City.Type)
+	assert.Equal(t, []string{"EZE", "AEP"}, metro.Airports)
+	assert.NoError(t, metro.Validate())
+
+	metro = MetroArea{
+		Code:     "BUEN",
+		Name:     map[Language]string{"xx": "Buenos Aires"},
+		City:     Ancestor{Type: RegionTypeCountry},
+		Airports: []string{"EZE", "A3P"},
+	}
+
+	assert.Equal(t, map[string]pkgErrors.Code{
+		"code":         pkgErrors.CodeInvalidIATACode,
+		"name.xx":      pkgErrors.CodeInvalidLanguage,
+		"country_code": pkgErrors.CodeInvalidCountryCode,
+		"city.id":      pkgErrors.CodeMissingParameter,
+		"city.type":    pkgErrors.CodeInvalidRegionType,
+		"airports[1]":  pkgErrors.CodeInvalidIATACode,
+	}, fieldCodes(t, metro.Validate()))
+}
+
+func TestValidateAliases(t *testing.T) {
+	assert.NoError(t, ValidateAliases(map[Language][]string{"es": {"CABA"}, "pt-BR": {"Baires"}}))
+
+	assert.Equal(t, map[string]pkgErrors.Code{"aliases": pkgErrors.CodeMissingParameter}, fieldCodes(t, ValidateAliases(nil)))
+	assert.Equal(t, map[string]pkgErrors.Code{"aliases.spanish": pkgErrors.CodeInvalidLanguage}, fieldCodes(t, ValidateAliases(map[Language][]string{"spanish": {"CABA"}})))
+}
+
+func TestIsISOLanguage(t *testing.T) {
+	assert.True(t, IsISOLanguage("es"))
+	assert.True(t, IsISOLanguage("pt-BR"))
+	assert.False(t, IsISOLanguage("pt-XX"))
+	assert.False(t, IsISOLanguage("spa"))
+	assert.False(t, IsISOLanguage("ES"))
+}
+
+func TestIsISOCountryCode(t *testing.T) {
+	assert.Len(t, isoCountries, 250)
+	assert.True(t, IsISOCountryCode("AR"))
+	assert.False(t, IsISOCountryCode("XX"))
+}
@@ -0,0 +1,390 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: geo/v2/geo.proto
+
+package geov2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GeoServiceClient is the client API for GeoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GeoServiceClient interface {
+	// GetRegion returns a region by type and id
+	GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*Region, error)
+	// ListRegions streams the regions of a type that match the filters, ordered by id
+	ListRegions(ctx context.Context, in *ListRegionsRequest, opts ...grpc.CallOption) (GeoService_ListRegionsClient, error)
+	// GetAirport returns an airport by IATA code
+	GetAirport(ctx context.Context, in *GetAirportRequest, opts ...grpc.CallOption) (*Airport, error)
+	// GetIntersectedRegions returns the id and type of the regions whose polygon intersects the geometry
+	GetIntersectedRegions(ctx context.Context, in *GetIntersectedRegionsRequest, opts ...grpc.CallOption) (*GetIntersectedRegionsResponse, error)
+	// GetNearbyRegions returns the regions in a radius sorted by distance
+	GetNearbyRegions(ctx context.Context, in *GetNearbyRegionsRequest, opts ...grpc.CallOption) (*GetNearbyRegionsResponse, error)
+	// GetPolygon returns the polygon of a region by id
+	GetPolygon(ctx context.Context, in *GetPolygonRequest, opts ...grpc.CallOption) (*GeoRegion, error)
+	// ExportRegions streams every region of the types, with its polygon when asked
+	ExportRegions(ctx context.Context, in *ExportRegionsRequest, opts ...grpc.CallOption) (GeoService_ExportRegionsClient, error)
+}
+
+type geoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeoServiceClient(cc grpc.ClientConnInterface) GeoServiceClient {
+	return &geoServiceClient{cc}
+}
+
+func (c *geoServiceClient) GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*Region, error) {
+	out := new(Region)
+	err := c.cc.Invoke(ctx, "/geo.v2.GeoService/GetRegion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) ListRegions(ctx context.Context, in *ListRegionsRequest, opts ...grpc.CallOption) (GeoService_ListRegionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoService_ServiceDesc.Streams[0], "/geo.v2.GeoService/ListRegions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoServiceListRegionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoService_ListRegionsClient interface {
+	Recv() (*Region, error)
+	grpc.ClientStream
+}
+
+type geoServiceListRegionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoServiceListRegionsClient) Recv() (*Region, error) {
+	m := new(Region)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *geoServiceClient) GetAirport(ctx context.Context, in *GetAirportRequest, opts ...grpc.CallOption) (*Airport, error) {
+	out := new(Airport)
+	err := c.cc.Invoke(ctx, "/geo.v2.GeoService/GetAirport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetIntersectedRegions(ctx context.Context, in *GetIntersectedRegionsRequest, opts ...grpc.CallOption) (*GetIntersectedRegionsResponse, error) {
+	out := new(GetIntersectedRegionsResponse)
+	err := c.cc.Invoke(ctx, "/geo.v2.GeoService/GetIntersectedRegions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetNearbyRegions(ctx context.Context, in *GetNearbyRegionsRequest, opts ...grpc.CallOption) (*GetNearbyRegionsResponse, error) {
+	out := new(GetNearbyRegionsResponse)
+	err := c.cc.Invoke(ctx, "/geo.v2.GeoService/GetNearbyRegions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetPolygon(ctx context.Context, in *GetPolygonRequest, opts ...grpc.CallOption) (*GeoRegion, error) {
+	out := new(GeoRegion)
+	err := c.cc.Invoke(ctx, "/geo.v2.GeoService/GetPolygon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) ExportRegions(ctx context.Context, in *ExportRegionsRequest, opts ...grpc.CallOption) (GeoService_ExportRegionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoService_ServiceDesc.Streams[1], "/geo.v2.GeoService/ExportRegions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoServiceExportRegionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoService_ExportRegionsClient interface {
+	Recv() (*ExportedRegion, error)
+	grpc.ClientStream
+}
+
+type geoServiceExportRegionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoServiceExportRegionsClient) Recv() (*ExportedRegion, error) {
+	m := new(ExportedRegion)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GeoServiceServer is the server API for GeoService service.
+// All implementations must embed UnimplementedGeoServiceServer
+// for forward compatibility
+type GeoServiceServer interface {
+	// GetRegion returns a region by type and id
+	GetRegion(context.Context, *GetRegionRequest) (*Region, error)
+	// ListRegions streams the regions of a type that match the filters, ordered by id
+	ListRegions(*ListRegionsRequest, GeoService_ListRegionsServer) error
+	// GetAirport returns an airport by IATA code
+	GetAirport(context.Context, *GetAirportRequest) (*Airport, error)
+	// GetIntersectedRegions returns the id and type of the regions whose polygon intersects the geometry
+	GetIntersectedRegions(context.Context, *GetIntersectedRegionsRequest) (*GetIntersectedRegionsResponse, error)
+	// GetNearbyRegions returns the regions in a radius sorted by distance
+	GetNearbyRegions(context.Context, *GetNearbyRegionsRequest) (*GetNearbyRegionsResponse, error)
+	// GetPolygon returns the polygon of a region by id
+	GetPolygon(context.Context, *GetPolygonRequest) (*GeoRegion, error)
+	// ExportRegions streams every region of the types, with its polygon when asked
+	ExportRegions(*ExportRegionsRequest, GeoService_ExportRegionsServer) error
+	mustEmbedUnimplementedGeoServiceServer()
+}
+
+// UnimplementedGeoServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGeoServiceServer struct {
+}
+
+func (UnimplementedGeoServiceServer) GetRegion(context.Context, *GetRegionRequest) (*Region, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegion not implemented")
+}
+func (UnimplementedGeoServiceServer) ListRegions(*ListRegionsRequest, GeoService_ListRegionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListRegions not implemented")
+}
+func (UnimplementedGeoServiceServer) GetAirport(context.Context, *GetAirportRequest) (*Airport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAirport not implemented")
+}
+func (UnimplementedGeoServiceServer) GetIntersectedRegions(context.Context, *GetIntersectedRegionsRequest) (*GetIntersectedRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIntersectedRegions not implemented")
+}
+func (UnimplementedGeoServiceServer) GetNearbyRegions(context.Context, *GetNearbyRegionsRequest) (*GetNearbyRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyRegions not implemented")
+}
+func (UnimplementedGeoServiceServer) GetPolygon(context.Context, *GetPolygonRequest) (*GeoRegion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolygon not implemented")
+}
+func (UnimplementedGeoServiceServer) ExportRegions(*ExportRegionsRequest, GeoService_ExportRegionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRegions not implemented")
+}
+func (UnimplementedGeoServiceServer) mustEmbedUnimplementedGeoServiceServer() {}
+
+// UnsafeGeoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeoServiceServer will
+// result in compilation errors.
+type UnsafeGeoServiceServer interface {
+	mustEmbedUnimplementedGeoServiceServer()
+}
+
+func RegisterGeoServiceServer(s grpc.ServiceRegistrar, srv GeoServiceServer) {
+	s.RegisterService(&GeoService_ServiceDesc, srv)
+}
+
+func _GeoService_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.v2.GeoService/GetRegion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetRegion(ctx, req.(*GetRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_ListRegions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRegionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoServiceServer).ListRegions(m, &geoServiceListRegionsServer{stream})
+}
+
+type GeoService_ListRegionsServer interface {
+	Send(*Region) error
+	grpc.ServerStream
+}
+
+type geoServiceListRegionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoServiceListRegionsServer) Send(m *Region) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GeoService_GetAirport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAirportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetAirport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.v2.GeoService/GetAirport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetAirport(ctx, req.(*GetAirportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetIntersectedRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIntersectedRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetIntersectedRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.v2.GeoService/GetIntersectedRegions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetIntersectedRegions(ctx, req.(*GetIntersectedRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetNearbyRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetNearbyRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.v2.GeoService/GetNearbyRegions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetNearbyRegions(ctx, req.(*GetNearbyRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetPolygon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPolygonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetPolygon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.v2.GeoService/GetPolygon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetPolygon(ctx, req.(*GetPolygonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_ExportRegions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRegionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoServiceServer).ExportRegions(m, &geoServiceExportRegionsServer{stream})
+}
+
+type GeoService_ExportRegionsServer interface {
+	Send(*ExportedRegion) error
+	grpc.ServerStream
+}
+
+type geoServiceExportRegionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoServiceExportRegionsServer) Send(m *ExportedRegion) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GeoService_ServiceDesc is the grpc.ServiceDesc for GeoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GeoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "geo.v2.GeoService",
+	HandlerType: (*GeoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRegion",
+			Handler:    _GeoService_GetRegion_Handler,
+		},
+		{
+			MethodName: "GetAirport",
+			Handler:    _GeoService_GetAirport_Handler,
+		},
+		{
+			MethodName: "GetIntersectedRegions",
+			Handler:    _GeoService_GetIntersectedRegions_Handler,
+		},
+		{
+			MethodName: "GetNearbyRegions",
+			Handler:    _GeoService_GetNearbyRegions_Handler,
+		},
+		{
+			MethodName: "GetPolygon",
+			Handler:    _GeoService_GetPolygon_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListRegions",
+			Handler:       _GeoService_ListRegions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportRegions",
+			Handler:       _GeoService_ExportRegions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "geo/v2/geo.proto",
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"fmt"
+
+	geoModel "github.com/basset-la/api-geo/model"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// QueryAccommodations for the accommodations inside a polygon.
+// Sorted by id they can be paginated with After, sorted by distance to the center only with Skip.
+type QueryAccommodations struct {
+	Within         geoModel.Geometry
+	Center         geoModel.Center
+	SortByDistance bool
+	Limit          int
+	Skip           int
+	After          string
+}
+
+func accommodationsQuery(q QueryAccommodations) bson.M {
+	return bson.M{
+		"type":             geoModel.RegionTypeAccommodation,
+		"bounding_polygon": bson.M{"$geoWithin": bson.M{"$geometry": q.Within}},
+	}
+}
+
+// GetAccommodationsWithin returns the accommodations inside the polygon with their distance to the center
+func (repo *MongoRepository) GetAccommodationsWithin(q QueryAccommodations) ([]geoModel.NearbyRegion, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
+
+	accommodations := make([]geoModel.NearbyRegion, 0)
+
+	if q.SortByDistance {
+		pipeline := []bson.M{{"$geoNear": bson.M{
+			"near":               geoModel.NewPointGeometry([]interface{}{q.Center.Longitude, q.Center.Latitude}),
+			"key":                "bounding_polygon",
+			"distanceField":      "distance_km",
+			"distanceMultiplier": 0.001,
+			"spherical":          true,
+			"query":              accommodationsQuery(q),
+		}}}
+
+		if q.Skip > 0 {
+			pipeline = append(pipeline, bson.M{"$skip": q.Skip})
+		}
+
+		pipeline = append(pipeline, bson.M{"$limit": q.Limit})
+
+		if err := col.Pipe(pipeline).All(&accommodations); err != nil {
+			return nil, fmt.Errorf("failed to get accommodations %w", err)
+		}
+	} else {
+		dbQuery := accommodationsQuery(q)
+
+		if q.After != "" {
+			dbQuery["geo_id"] = bson.M{"$gt": q.After}
+		}
+
+		err := col.Find(dbQuery).Sort("geo_id").Skip(q.Skip).Limit(q.Limit).All(&accommodations)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accommodations %w", err)
+		}
+	}
+
+	for i := range accommodations {
+		a := &accommodations[i]
+		a.MapCoordinates()
+
+		if !q.SortByDistance && a.Geometry.Type == geoModel.GeometryPoint && len(a.Geometry.Point) == 2 {
+			a.DistanceKm = geoModel.DistanceKm(q.Center, geoModel.Center{Longitude: a.Geometry.Point[0], Latitude: a.Geometry.Point[1]})
+		}
+	}
+
+	return accommodations, nil
+}
+
+// CountAccommodationsWithin returns how many accommodations are inside the polygon
+func (repo *MongoRepository) CountAccommodationsWithin(q QueryAccommodations) (int, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	count, err := s.DB(repo.db).C(repo.geoCoordinatesTable).Find(accommodationsQuery(q)).Count()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count accommodations %w", err)
+	}
+
+	return count, nil
+}
+
+// QueryClusters for the accommodations in a bounding box grouped in cells of CellSize degrees.
+// When CellSize is 0 each accommodation is returned as a cluster of its own, up to Limit of them.
+type QueryClusters struct {
+	BoundingBox geoModel.BoundingBox
+	CellSize    float64
+	Sample      int
+	Limit       int
+}
+
+// GetAccommodationClusters groups the accommodation points inside the bounding box in a grid
+func (repo *MongoRepository) GetAccommodationClusters(q QueryClusters) ([]geoModel.AccommodationCluster, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	match := bson.M{
+		"type":                  geoModel.RegionTypeAccommodation,
+		"bounding_polygon.type": geoModel.GeometryPoint,
+		"bounding_polygon":      bson.M{"$geoWithin": bson.M{"$geometry": q.BoundingBox.Geometry()}},
+	}
+
+	longitude := bson.M{"$arrayElemAt": []interface{}{"$bounding_polygon.coordinates", 0}}
+	latitude := bson.M{"$arrayElemAt": []interface{}{"$bounding_polygon.coordinates", 1}}
+
+	pipeline := []bson.M{{"$match": match}}
+
+	if q.CellSize > 0 {
+		cell := func(coordinate bson.M, offset float64) bson.M {
+			return bson.M{"$floor": bson.M{"$divide": []interface{}{bson.M{"$add": []interface{}{coordinate, offset}}, q.CellSize}}}
+		}
+
+		pipeline = append(pipeline,
+			bson.M{"$group": bson.M{
+				"_id":       bson.M{"x": cell(longitude, 180), "y": cell(latitude, 90)},
+				"count":     bson.M{"$sum": 1},
+				"longitude": bson.M{"$avg": longitude},
+				"latitude":  bson.M{"$avg": latitude},
+				"ids":       bson.M{"$push": "$geo_id"},
+			}},
+			bson.M{"$project": bson.M{
+				"_id":   0,
+				"count": 1,
+				"centroid": bson.M{
+					"center_longitude": "$longitude",
+					"center_latitude":  "$latitude",
+				},
+				"ids": bson.M{"$slice": []interface{}{"$ids", q.Sample}},
+			}},
+		)
+	} else {
+		if q.Limit > 0 {
+			pipeline = append(pipeline, bson.M{"$limit": q.Limit})
+		}
+
+		pipeline = append(pipeline, bson.M{"$project": bson.M{
+			"_id":   0,
+			"count": bson.M{"$literal": 1},
+			"centroid": bson.M{
+				"center_longitude": longitude,
+				"center_latitude":  latitude,
+			},
+			"ids": []interface{}{"$geo_id"},
+		}})
+	}
+
+	clusters := make([]geoModel.AccommodationCluster, 0)
+
+	err := s.DB(repo.db).C(repo.geoCoordinatesTable).Pipe(pipeline).AllowDiskUse().All(&clusters)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accommodation clusters %w", err)
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+
+	geoModel "github.com/basset-la/api-geo/model"
+	log "github.com/sirupsen/logrus"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// EnsureAliasIndexes creates the index of the alias names used by the name lookups of each region type
+func (repo *MongoRepository) EnsureAliasIndexes() error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	for _, rt := range geoModel.RegionTypes() {
+		err := s.DB(repo.db).C(string(rt)).EnsureIndex(mgo.Index{Key: []string{"alias_names"}, Sparse: true, Background: true})
+
+		if err != nil {
+			return fmt.Errorf("failed to create alias names index of %s. %w", rt, err)
+		}
+	}
+
+	return nil
+}
+
+// BackfillAliasNames sets the alias names of the regions with aliases stored before they were flattened on write
+func (repo *MongoRepository) BackfillAliasNames() (int, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	updated := 0
+
+	for _, rt := range geoModel.RegionTypes() {
+		col := s.DB(repo.db).C(string(rt))
+		iter := col.Find(bson.M{"aliases": bson.M{"$exists": true}, "alias_names": bson.M{"$exists": false}}).
+			Select(bson.M{"_id": 1, "aliases": 1}).Iter()
+
+		var r geoModel.Region
+		for iter.Next(&r) {
+			if err := col.UpdateId(r.ID, bson.M{"$set": bson.M{"alias_names": geoModel.AliasNames(r.Aliases)}}); err != nil {
+				log.Error(fmt.Errorf("failed to set alias names of region %s. %w", r.ID.Hex(), err))
+			} else {
+				updated++
+			}
+
+			r = geoModel.Region{}
+		}
+
+		if err := iter.Close(); err != nil {
+			return updated, fmt.Errorf("failed to backfill alias names of %s. %w", rt, err)
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	geoModel "github.com/basset-la/api-geo/model"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// customAreaAncestorTypes are the curated regions a custom area can be inside of
+var customAreaAncestorTypes = []geoModel.RegionType{
+	geoModel.RegionTypeContinent,
+	geoModel.RegionTypeCountry,
+	geoModel.RegionTypeHighLevelRegion,
+	geoModel.RegionTypeProvinceState,
+	geoModel.RegionTypeMultiCityVicinity,
+	geoModel.RegionTypeCity,
+	geoModel.RegionTypeNeighborhood,
+}
+
+// QueryCustomArea for custom areas
+type QueryCustomArea struct {
+	Owner      string
+	Tag        string
+	AncestorID string
+	Limit      int
+	After      string
+}
+
+// SaveCustomArea saves a custom area and its polygon, the id, center and ancestors are filled from the geometry
+func (repo *MongoRepository) SaveCustomArea(a *geoModel.CustomArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	a.ID = bson.NewObjectId()
+	a.GeoID = geoModel.NewCustomAreaID()
+	a.Type = geoModel.RegionTypeCustomArea
+
+	if err := repo.fillCustomArea(a); err != nil {
+		return err
+	}
+
+	col := s.DB(repo.db).C(string(geoModel.RegionTypeCustomArea))
+
+	if err := col.Insert(a); err != nil {
+		return fmt.Errorf("failed to save custom area. %w", err)
+	}
+
+	if err := repo.SaveGeoRegion(a.GeoRegion()); err != nil {
+		_ = col.Remove(bson.M{"geo_id": a.GeoID})
+
+		return fmt.Errorf("failed to save custom area polygon. %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCustomArea replaces the name, owner, tags and geometry of a custom area
+func (repo *MongoRepository) UpdateCustomArea(a *geoModel.CustomArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	a.Type = geoModel.RegionTypeCustomArea
+
+	if err := repo.fillCustomArea(a); err != nil {
+		return err
+	}
+
+	col := s.DB(repo.db).C(string(geoModel.RegionTypeCustomArea))
+
+	err := col.Update(bson.M{"geo_id": a.GeoID}, bson.M{"$set": bson.M{
+		"name":         a.Name,
+		"owner":        a.Owner,
+		"tags":         a.Tags,
+		"coordinates":  a.Center,
+		"ancestors":    a.Ancestors,
+		"country_code": a.CountryCode,
+		"timezone":     a.Timezone,
+	}})
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("custom area %s not found. %w", a.GeoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to update custom area. %w", err)
+	}
+
+	err = s.DB(repo.db).C(repo.geoCoordinatesTable).Update(bson.M{"geo_id": a.GeoID}, bson.M{"$set": bson.M{"bounding_polygon": a.Geometry}})
+
+	if err != nil {
+		return fmt.Errorf("failed to update custom area polygon. %w", err)
+	}
+
+	return nil
+}
+
+// GetCustomArea returns a custom area with its geometry
+func (repo *MongoRepository) GetCustomArea(geoID string, a *geoModel.CustomArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(string(geoModel.RegionTypeCustomArea))
+
+	err := col.Find(bson.M{"geo_id": geoID}).One(a)
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("custom area %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to get custom area %s. %w", geoID, err)
+	}
+
+	var polygon geoModel.GeoRegion
+
+	if err = repo.GetGeoRegion(geoID, &polygon); err != nil {
+		return err
+	}
+
+	a.Geometry = &polygon.Geometry
+
+	return nil
+}
+
+// GetCustomAreas returns a page of custom areas sorted by id, without their geometries
+func (repo *MongoRepository) GetCustomAreas(q QueryCustomArea, a *[]geoModel.CustomArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(string(geoModel.RegionTypeCustomArea))
+
+	dbQuery := bson.M{}
+
+	if q.Owner != "" {
+		dbQuery["owner"] = q.Owner
+	}
+
+	if q.Tag != "" {
+		dbQuery["tags"] = q.Tag
+	}
+
+	if q.AncestorID != "" {
+		dbQuery["ancestors.geo_id"] = q.AncestorID
+	}
+
+	if q.After != "" {
+		dbQuery["geo_id"] = bson.M{"$gt": q.After}
+	}
+
+	err := col.Find(dbQuery).Sort("geo_id").Limit(q.Limit).All(a)
+
+	if err != nil {
+		return fmt.Errorf("failed to get custom areas. %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCustomArea removes a custom area and its polygon
+func (repo *MongoRepository) DeleteCustomArea(geoID string) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	err := s.DB(repo.db).C(string(geoModel.RegionTypeCustomArea)).Remove(bson.M{"geo_id": geoID})
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("custom area %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to delete custom area %s. %w", geoID, err)
+	}
+
+	err = s.DB(repo.db).C(repo.geoCoordinatesTable).Remove(bson.M{"geo_id": geoID})
+
+	if err != nil && !errors.Is(err, mgo.ErrNotFound) {
+		return fmt.Errorf("failed to delete custom area %s polygon. %w", geoID, err)
+	}
+
+	return nil
+}
+
+// fillCustomArea sets the center at the label point of the geometry and the curated regions that contain it as ancestors
+func (repo *MongoRepository) fillCustomArea(a *geoModel.CustomArea) error {
+	metrics, err := geoModel.NewPolygonMetrics(*a.Geometry)
+	if err != nil {
+		return fmt.Errorf("invalid custom area geometry. %w", err)
+	}
+
+	a.Center = metrics.LabelPoint
+
+	regions := make([]geoModel.GeoRegion, 0)
+	point := geoModel.NewPointGeometry([]interface{}{a.Center.Longitude, a.Center.Latitude})
+
+	err = repo.GetIntersectedRegions(*point, customAreaAncestorTypes, &regions)
+	if err != nil && !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return err
+	}
+
+	a.Ancestors = make([]geoModel.Ancestor, 0, len(regions))
+	a.CountryCode = ""
+
+	for _, r := range regions {
+		a.Ancestors = append(a.Ancestors, geoModel.Ancestor{ID: r.GeoID, Type: r.Type})
+
+		if r.Type == geoModel.RegionTypeCountry {
+			var country geoModel.Region
+
+			if err = repo.GetRegionByTypeAndGeoID(r.Type, r.GeoID, &country); err == nil {
+				a.CountryCode = country.CountryCode
+			}
+		}
+	}
+
+	a.Timezone = ""
+	repo.fillRegionTimezone(&a.Region)
+
+	return nil
+}
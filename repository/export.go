@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"fmt"
+
+	geoModel "github.com/basset-la/api-geo/model"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ExportRegions calls fn with every region of the types, one at a time, sorted by id.
+// With polygons the geometries of geoCoordinatesTable are joined by geo_id.
+func (repo *MongoRepository) ExportRegions(regionTypes []geoModel.RegionType, polygons bool, fn func(r *geoModel.RegionExport) error) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	for _, regionType := range regionTypes {
+		col := s.DB(repo.db).C(string(regionType))
+
+		pipeline := []bson.M{{"$sort": bson.M{"geo_id": 1}}}
+
+		if polygons {
+			pipeline = append(pipeline, bson.M{"$lookup": bson.M{
+				"from":         repo.geoCoordinatesTable,
+				"localField":   "geo_id",
+				"foreignField": "geo_id",
+				"as":           "polygons",
+			}})
+		}
+
+		iter := col.Pipe(pipeline).AllowDiskUse().Batch(500).Iter()
+
+		var r geoModel.RegionExport
+		for iter.Next(&r) {
+			if err := fn(&r); err != nil {
+				_ = iter.Close()
+
+				return err
+			}
+
+			r = geoModel.RegionExport{}
+		}
+
+		if err := iter.Close(); err != nil {
+			return fmt.Errorf("failed to export %s. %w", regionType, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+
+	geoModel "github.com/basset-la/api-geo/model"
+	log "github.com/sirupsen/logrus"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// geohashPrefix matches the geohashes starting with the prefix, it must be a valid geohash
+func geohashPrefix(prefix string) bson.M {
+	return bson.M{"geohash": bson.RegEx{Pattern: "^" + prefix}}
+}
+
+// GetByGeohash returns the point regions and the airports inside the geohash cell, up to limit of each
+func (repo *MongoRepository) GetByGeohash(prefix string, limit int) ([]geoModel.GeoRegion, []geoModel.AirportV2, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	regions := make([]geoModel.GeoRegion, 0)
+
+	err := s.DB(repo.db).C(repo.geoCoordinatesTable).Find(geohashPrefix(prefix)).Sort("geohash").Limit(limit).All(&regions)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get regions by geohash %s. %w", prefix, err)
+	}
+
+	for i := range regions {
+		regions[i].MapCoordinates()
+	}
+
+	airports := make([]geoModel.AirportV2, 0)
+
+	err = s.DB(repo.db).C(repo.airportTable).Find(geohashPrefix(prefix)).Sort("geohash").Limit(limit).All(&airports)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get airports by geohash %s. %w", prefix, err)
+	}
+
+	return regions, airports, nil
+}
+
+// EnsureGeohashIndexes creates the indexes used by the prefix lookups
+func (repo *MongoRepository) EnsureGeohashIndexes() error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	for _, table := range []string{repo.geoCoordinatesTable, repo.airportTable} {
+		err := s.DB(repo.db).C(table).EnsureIndex(mgo.Index{Key: []string{"geohash"}, Sparse: true, Background: true})
+
+		if err != nil {
+			return fmt.Errorf("failed to create geohash index of %s. %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// BackfillGeohashes sets the geohash of the point regions and the airports without one
+func (repo *MongoRepository) BackfillGeohashes() (int, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	updated := 0
+
+	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
+	iter := col.Find(bson.M{"geohash": bson.M{"$exists": false}, "bounding_polygon.type": geoModel.GeometryPoint}).
+		Select(bson.M{"_id": 1, "bounding_polygon": 1}).Iter()
+
+	var r geoModel.GeoRegion
+	for iter.Next(&r) {
+		r.FillGeohash()
+
+		if r.Geohash != "" {
+			if err := col.UpdateId(r.ID, bson.M{"$set": bson.M{"geohash": r.Geohash}}); err != nil {
+				log.Error(fmt.Errorf("failed to set geohash of region %s. %w", r.ID.Hex(), err))
+			} else {
+				updated++
+			}
+		}
+
+		r = geoModel.GeoRegion{}
+	}
+
+	if err := iter.Close(); err != nil {
+		return updated, fmt.Errorf("failed to backfill geohashes of regions. %w", err)
+	}
+
+	col = s.DB(repo.db).C(repo.airportTable)
+	iter = col.Find(bson.M{"geohash": bson.M{"$exists": false}}).Select(bson.M{"iata": 1, "coordinates": 1}).Iter()
+
+	var a geoModel.AirportV2
+	for iter.Next(&a) {
+		a.FillGeohash()
+
+		if a.Geohash != "" {
+			if err := col.Update(bson.M{"iata": a.IataCode}, bson.M{"$set": bson.M{"geohash": a.Geohash}}); err != nil {
+				log.Error(fmt.Errorf("failed to set geohash of airport %s. %w", a.IataCode, err))
+			} else {
+				updated++
+			}
+		}
+
+		a = geoModel.AirportV2{}
+	}
+
+	if err := iter.Close(); err != nil {
+		return updated, fmt.Errorf("failed to backfill geohashes of airports. %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	geoModel "github.com/basset-la/api-geo/model"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SaveMetroArea saves a metropolitan area in mongoDB, it is only inserted when there is none with its code
+func (repo *MongoRepository) SaveMetroArea(m *geoModel.MetroArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	m.ID = bson.NewObjectId()
+	col := s.DB(repo.db).C(repo.metroAreaTable)
+
+	info, err := col.Upsert(bson.M{"code": m.Code}, bson.M{"$setOnInsert": m})
+
+	if err != nil {
+		return fmt.Errorf("failed to save metro area. %w", err)
+	}
+
+	if info.Matched > 0 {
+		return pkgErrors.New(pkgErrors.CodeMetroAreaExists, "metro area %s already exists", m.Code).WithField("code")
+	}
+
+	return nil
+}
+
+// UpdateMetroArea updates a metropolitan area in mongoDB
+func (repo *MongoRepository) UpdateMetroArea(m *geoModel.MetroArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.metroAreaTable)
+
+	err := col.Update(bson.M{"code": m.Code}, bson.M{"$set": bson.M{
+		"name":         m.Name,
+		"country_code": m.CountryCode,
+		"city":         m.City,
+		"airports":     m.Airports,
+	}})
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("metro area %s not found. %w", m.Code, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to update metro area. %w", err)
+	}
+
+	return nil
+}
+
+// GetMetroAreaByCode returns a metropolitan area by its IATA code
+func (repo *MongoRepository) GetMetroAreaByCode(code string, m *geoModel.MetroArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.metroAreaTable)
+
+	err := col.Find(bson.M{"code": code}).One(m)
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("metro area %s not found. %w", code, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to get metro area %s. %w", code, err)
+	}
+
+	return nil
+}
+
+// GetMetroAreas returns a slice of metropolitan areas
+func (repo *MongoRepository) GetMetroAreas(q QueryMetroArea, m *[]geoModel.MetroArea) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.metroAreaTable)
+
+	dbQuery := bson.M{}
+
+	if q.CountryCode != "" {
+		dbQuery["country_code"] = q.CountryCode
+	}
+
+	if q.CityID != "" {
+		dbQuery["city.geo_id"] = q.CityID
+	}
+
+	if q.Airport != "" {
+		dbQuery["airports"] = q.Airport
+	}
+
+	err := col.Find(dbQuery).All(m)
+
+	if err != nil {
+		return fmt.Errorf("failed to get metro areas %w", err)
+	}
+
+	return nil
+}
+
+// ExpandAirportCode returns the airports of a metropolitan area code, of the cities with the code or the airport itself
+// for an airport code. cityIDs are the geo ids of the cities with the code, their IATA codes are kept in V1.
+func (repo *MongoRepository) ExpandAirportCode(code string, cityIDs []string, a *[]geoModel.AirportV2) error {
+	var metro geoModel.MetroArea
+
+	err := repo.GetMetroAreaByCode(code, &metro)
+
+	if err == nil {
+		if len(metro.Airports) == 0 {
+			return nil
+		}
+
+		return repo.GetAirportByQuery(QueryAirport{IataCodes: metro.Airports}, a)
+	}
+
+	if !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return err
+	}
+
+	if len(cityIDs) > 0 {
+		if err = repo.GetAirportByQuery(QueryAirport{RegionIDs: cityIDs, RegionType: geoModel.RegionTypeCity}, a); err != nil {
+			return err
+		}
+
+		if len(*a) > 0 {
+			return nil
+		}
+	}
+
+	var airport geoModel.AirportV2
+
+	if err = repo.GetAirportByIATACode(code, &airport); err != nil {
+		return err
+	}
+
+	*a = append(*a, airport)
+
+	return nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	pkgErrors "github.com/basset-la/api-geo/errors"
 	geoModel "github.com/basset-la/api-geo/model"
@@ -11,22 +13,45 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
-const earthRadius float64 = 6378.1
-
 type Repository interface {
 	GetRegionByTypeAndGeoID(regionType geoModel.RegionType, geoID string, r *geoModel.Region) error
 	GetRegions(query QueryRegion, r *[]geoModel.Region) error
 	GetCountryByCountryCode(countryCode string, r *geoModel.Region) error
-	Count(regionType geoModel.RegionType) (int, error)
+	Count(query QueryRegion) (int, error)
 	SaveRegion(e *geoModel.Region) error
 	UpdateRegion(e *geoModel.Region) error
+	DeleteRegion(regionType geoModel.RegionType, geoID string) error
+	GetRegionGeoIDs(regionType geoModel.RegionType) ([]string, error)
 	SaveAirport(e *geoModel.AirportV2) error
 	UpdateAirport(e *geoModel.AirportV2) error
 	GetAirportByIATACode(iataCode string, a *geoModel.AirportV2) error
 	GetAirportByQuery(q QueryAirport, a *[]geoModel.AirportV2) error
+	CountAirports(q QueryAirport) (int, error)
+	GetGeoRegions(geoIDs []string) ([]geoModel.GeoRegion, error)
 	GetIntersectedRegions(geometry geoModel.Geometry, regionTypes []geoModel.RegionType, r *[]geoModel.GeoRegion) error
+	GetRegionIDsWithin(geometry geoModel.Geometry, regionType geoModel.RegionType, limit int) ([]string, error)
 	InsertAccommodation(accommodation *geoModel.GeoRegion) ([]geoModel.GeoRegion, error)
-	GetNearByRegions(latitude float64, longitude float64, regionTypes []geoModel.RegionType, radius float64) ([]geoModel.GeoRegion, error)
+	GetNearByRegions(q QueryNearby) ([]geoModel.NearbyRegion, error)
+	UpdateRegionCenter(regionType geoModel.RegionType, geoID string, center geoModel.Center) error
+	SaveCustomArea(a *geoModel.CustomArea) error
+	UpdateCustomArea(a *geoModel.CustomArea) error
+	GetCustomArea(geoID string, a *geoModel.CustomArea) error
+	GetCustomAreas(q QueryCustomArea, a *[]geoModel.CustomArea) error
+	DeleteCustomArea(geoID string) error
+	GetAccommodationsWithin(q QueryAccommodations) ([]geoModel.NearbyRegion, error)
+	CountAccommodationsWithin(q QueryAccommodations) (int, error)
+	GetAccommodationClusters(q QueryClusters) ([]geoModel.AccommodationCluster, error)
+	GetByGeohash(prefix string, limit int) ([]geoModel.GeoRegion, []geoModel.AirportV2, error)
+	AddRegionAliases(regionType geoModel.RegionType, geoID string, aliases map[geoModel.Language][]string) error
+	SetRegionAliases(regionType geoModel.RegionType, geoID string, aliases map[geoModel.Language][]string) error
+	RemoveRegionAlias(regionType geoModel.RegionType, geoID string, language geoModel.Language, alias string) error
+	GetTimezone(latitude float64, longitude float64) (string, error)
+	SaveTimezoneRegion(tz *geoModel.TimezoneRegion) error
+	SaveMetroArea(m *geoModel.MetroArea) error
+	UpdateMetroArea(m *geoModel.MetroArea) error
+	GetMetroAreaByCode(code string, m *geoModel.MetroArea) error
+	GetMetroAreas(q QueryMetroArea, m *[]geoModel.MetroArea) error
+	ExpandAirportCode(code string, cityIDs []string, a *[]geoModel.AirportV2) error
 }
 
 // QueryRegion for regions
@@ -35,29 +60,59 @@ type QueryRegion struct {
 	CountryCode         string
 	GeoIDs              []string
 	Descendants         []string
-	Basic               bool
+	Fields              bson.M
 	Page                int
 	Limit               int
 	Ancestors           []string
 	AncestorsRegionType geoModel.RegionType
+	AncestorID          string
+	AncestorType        geoModel.RegionType
+	BoundingBox         *geoModel.BoundingBox
+	Name                string
+	NameLanguages       []geoModel.Language
+	After               string
 }
 
 // QueryAirport for airports
 type QueryAirport struct {
 	CountryCode string
 	IataCodes   []string
+	RegionIDs   []string
+	RegionType  geoModel.RegionType
+	Limit       int
+	After       string
+	Fields      bson.M
+}
+
+// QueryNearby for regions around a point, distances are in kilometers
+type QueryNearby struct {
+	Latitude    float64
+	Longitude   float64
+	RegionTypes []geoModel.RegionType
+	Radius      float64
+	MinDistance float64
+	Limit       int
+}
+
+// QueryMetroArea for metropolitan areas
+type QueryMetroArea struct {
+	CountryCode string
+	CityID      string
+	Airport     string
 }
 
 // MongoRepository handles all requests to MongoDB
 type MongoRepository struct {
 	Session             *mgo.Session
 	airportTable        string
+	metroAreaTable      string
 	geoCoordinatesTable string
+	timezonesTable      string
 	db                  string
 }
 
 // NewMongoRepository creates a new mongo repository
-func NewMongoRepository(url, db, airportTable, geoCoordinatesTable string) (*MongoRepository, error) {
+func NewMongoRepository(url, db, airportTable, metroAreaTable, geoCoordinatesTable, timezonesTable string) (*MongoRepository, error) {
 	s, err := mgo.Dial(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connecto to mongodb. %w", err)
@@ -66,7 +121,9 @@ func NewMongoRepository(url, db, airportTable, geoCoordinatesTable string) (*Mon
 	r := &MongoRepository{
 		Session:             s,
 		airportTable:        airportTable,
+		metroAreaTable:      metroAreaTable,
 		geoCoordinatesTable: geoCoordinatesTable,
+		timezonesTable:      timezonesTable,
 		db:                  db,
 	}
 
@@ -99,10 +156,16 @@ func (repo *MongoRepository) GetRegionByTypeAndGeoID(regionType geoModel.RegionT
 
 // SaveRegion saves a region in mongoDB
 func (repo *MongoRepository) SaveRegion(r *geoModel.Region) error {
+	if err := regionCollection(r.Type); err != nil {
+		return err
+	}
+
 	s := repo.Session.Copy()
 	defer s.Close()
 
 	r.ID = bson.NewObjectId()
+	r.FillAliasNames()
+	repo.fillRegionTimezone(r)
 	col := s.DB(repo.db).C(string(r.Type))
 
 	if err := col.Insert(r); err != nil {
@@ -114,9 +177,15 @@ func (repo *MongoRepository) SaveRegion(r *geoModel.Region) error {
 
 // UpdateRegion saves a region in mongoDB
 func (repo *MongoRepository) UpdateRegion(e *geoModel.Region) error {
+	if err := regionCollection(e.Type); err != nil {
+		return err
+	}
+
 	s := repo.Session.Copy()
 	defer s.Close()
 
+	e.FillAliasNames()
+	repo.fillRegionTimezone(e)
 	col := s.DB(repo.db).C(string(e.Type))
 	err := col.Update(bson.M{"geo_id": e.GeoID}, e)
 
@@ -127,12 +196,71 @@ func (repo *MongoRepository) UpdateRegion(e *geoModel.Region) error {
 	return nil
 }
 
+// regionCollection rejects the types that are not curated region collections, the type is the collection name
+// so a typo would create a new collection
+func regionCollection(regionType geoModel.RegionType) error {
+	for _, rt := range geoModel.RegionTypes() {
+		if rt == regionType {
+			return nil
+		}
+	}
+
+	return pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", regionType).WithField("type")
+}
+
+// DeleteRegion removes a region and its polygons
+func (repo *MongoRepository) DeleteRegion(regionType geoModel.RegionType, geoID string) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	err := s.DB(repo.db).C(string(regionType)).Remove(bson.M{"geo_id": geoID})
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("region %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to delete region %s. %w", geoID, err)
+	}
+
+	_, err = s.DB(repo.db).C(repo.geoCoordinatesTable).RemoveAll(bson.M{"geo_id": geoID, "type": regionType})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete region %s polygons. %w", geoID, err)
+	}
+
+	return nil
+}
+
+// GetRegionGeoIDs returns the geo ids of all the regions of a type
+func (repo *MongoRepository) GetRegionGeoIDs(regionType geoModel.RegionType) ([]string, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	ids := make([]string, 0)
+	iter := s.DB(repo.db).C(string(regionType)).Find(nil).Select(bson.M{"geo_id": 1}).Iter()
+
+	var r geoModel.BaseRegion
+
+	for iter.Next(&r) {
+		ids = append(ids, r.GeoID)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to get %s geo ids. %w", regionType, err)
+	}
+
+	return ids, nil
+}
+
 // SaveAirport saves a airport in mongoDB
 func (repo *MongoRepository) SaveAirport(a *geoModel.AirportV2) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
 	a.ID = bson.NewObjectId()
+	a.FillGeohash()
+	repo.fillAirportTimezone(a)
 	col := s.DB(repo.db).C(repo.airportTable)
 
 	if err := col.Insert(a); err != nil {
@@ -147,6 +275,8 @@ func (repo *MongoRepository) UpdateAirport(a *geoModel.AirportV2) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
+	a.FillGeohash()
+	repo.fillAirportTimezone(a)
 	col := s.DB(repo.db).C(repo.airportTable)
 	err := col.Update(bson.M{"iata": a.IataCode}, a)
 
@@ -199,11 +329,32 @@ func (repo *MongoRepository) GetGeoRegion(geoID string, r *geoModel.GeoRegion) e
 	return nil
 }
 
+// GetGeoRegions returns the geo regions of the ids, of any type, the missing ones are skipped
+func (repo *MongoRepository) GetGeoRegions(geoIDs []string) ([]geoModel.GeoRegion, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
+
+	regions := make([]geoModel.GeoRegion, 0, len(geoIDs))
+
+	if err := col.Find(bson.M{"geo_id": bson.M{"$in": geoIDs}}).All(&regions); err != nil {
+		return nil, fmt.Errorf("failed to get geo regions %w", err)
+	}
+
+	for i := range regions {
+		regions[i].MapCoordinates()
+	}
+
+	return regions, nil
+}
+
 // UpdateGeoRegion update a geo region in mongodb
 func (repo *MongoRepository) UpdateGeoRegion(r *geoModel.GeoRegion) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
+	r.FillGeohash()
 	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
 	err := col.Update(bson.M{"geo_id": r.GeoID}, r)
 
@@ -214,22 +365,82 @@ func (repo *MongoRepository) UpdateGeoRegion(r *geoModel.GeoRegion) error {
 	return nil
 }
 
-func (repo *MongoRepository) Count(regionType geoModel.RegionType) (count int, err error) {
+// UpdateRegionCenter replaces the center of a region
+func (repo *MongoRepository) UpdateRegionCenter(regionType geoModel.RegionType, geoID string, center geoModel.Center) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
 	col := s.DB(repo.db).C(string(regionType))
+	err := col.Update(bson.M{"geo_id": geoID}, bson.M{"$set": bson.M{"coordinates": center}})
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("region %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to update region %s center. %w", geoID, err)
+	}
 
-	return col.Count()
+	return nil
 }
 
-// GetRegions returns a slice of regions
+// Count returns how many regions match the query, the pagination is ignored
+func (repo *MongoRepository) Count(query QueryRegion) (count int, err error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(string(query.RegionType))
+
+	count, err = col.Find(regionsQuery(query)).Count()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count regions %w", err)
+	}
+
+	return count, nil
+}
+
+// GetRegions returns a slice of regions ordered by geo id
 func (repo *MongoRepository) GetRegions(query QueryRegion, r *[]geoModel.Region) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
 	col := s.DB(repo.db).C(string(query.RegionType))
 
+	dbQuery := regionsQuery(query)
+
+	if query.After != "" {
+		dbQuery["geo_id"] = mergeCondition(dbQuery["geo_id"], bson.M{"$gt": query.After})
+	}
+
+	q := col.Find(dbQuery).Sort("geo_id")
+
+	if query.Page > 0 && query.Limit > 0 {
+		q = q.Skip((query.Page - 1) * query.Limit)
+	}
+
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+
+	if len(query.Fields) > 0 {
+		q = q.Select(query.Fields)
+	}
+
+	err := q.All(r)
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return pkgErrors.ErrEntityNotFound
+		}
+
+		return fmt.Errorf("failed to get regions %w", err)
+	}
+
+	return nil
+}
+
+func regionsQuery(query QueryRegion) bson.M {
 	dbQuery := bson.M{}
 
 	if len(query.GeoIDs) > 0 {
@@ -250,37 +461,73 @@ func (repo *MongoRepository) GetRegions(query QueryRegion, r *[]geoModel.Region)
 		dbQuery["country_code"] = query.CountryCode
 	}
 
-	if len(query.Ancestors) > 0 && len(query.AncestorsRegionType) > 0 {
-		dbQuery["ancestors.type"] = bson.M{
-			"$eq": query.AncestorsRegionType,
-			"$ne": query.RegionType,
-		}
-		dbQuery["ancestors.geo_id"] = bson.M{
-			"$in": query.Ancestors,
+	ancestors := make([]bson.M, 0, 2)
+
+	if len(query.Ancestors) > 0 && len(query.AncestorsRegionType) > 0 && query.AncestorsRegionType != query.RegionType {
+		ancestors = append(ancestors, bson.M{"$elemMatch": bson.M{
+			"type":   query.AncestorsRegionType,
+			"geo_id": bson.M{"$in": query.Ancestors},
+		}})
+	}
+
+	if query.AncestorID != "" {
+		ancestor := bson.M{"geo_id": query.AncestorID}
+
+		if query.AncestorType != "" {
+			ancestor["type"] = query.AncestorType
 		}
+
+		ancestors = append(ancestors, bson.M{"$elemMatch": ancestor})
 	}
 
-	q := col.Find(dbQuery)
-	if query.Page > 0 && query.Limit > 0 {
-		q = q.Skip((query.Page - 1) * query.Limit).Limit(query.Limit)
+	if len(ancestors) > 0 {
+		dbQuery["ancestors"] = bson.M{"$all": ancestors}
 	}
 
-	var err error
-	if query.Basic {
-		err = q.Select(bson.M{"geo_id": 1, "name": 1}).All(r)
-	} else {
-		err = q.All(r)
+	if b := query.BoundingBox; b != nil {
+		dbQuery["coordinates.center_latitude"] = bson.M{"$gte": b.MinLatitude, "$lte": b.MaxLatitude}
+
+		if b.CrossesAntimeridian() {
+			dbQuery["$and"] = []bson.M{{"$or": []bson.M{
+				{"coordinates.center_longitude": bson.M{"$gte": b.MinLongitude}},
+				{"coordinates.center_longitude": bson.M{"$lte": b.MaxLongitude}},
+			}}}
+		} else {
+			dbQuery["coordinates.center_longitude"] = bson.M{"$gte": b.MinLongitude, "$lte": b.MaxLongitude}
+		}
 	}
 
-	if err != nil {
-		if errors.Is(err, mgo.ErrNotFound) {
-			return pkgErrors.ErrEntityNotFound
+	if query.Name != "" {
+		dbQuery["$or"] = nameQuery(query.Name, query.NameLanguages)
+	}
+
+	return dbQuery
+}
+
+// mergeCondition adds operators to an existing field condition
+func mergeCondition(current interface{}, condition bson.M) bson.M {
+	if m, ok := current.(bson.M); ok {
+		for k, v := range condition {
+			m[k] = v
 		}
 
-		return fmt.Errorf("failed to get regions %w", err)
+		return m
 	}
 
-	return nil
+	return condition
+}
+
+// nameQuery matches a name in any of the languages or an alias in every language, ignoring case
+func nameQuery(name string, languages []geoModel.Language) []bson.M {
+	pattern := bson.RegEx{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"}
+
+	or := make([]bson.M, 0, len(languages)+1)
+
+	for _, l := range languages {
+		or = append(or, bson.M{"name." + string(l): pattern})
+	}
+
+	return append(or, bson.M{"alias_names": strings.ToLower(name)})
 }
 
 // GetCountryByCountryCode returns a country for a given country code
@@ -311,13 +558,59 @@ func (repo *MongoRepository) GetCountryByCountryCode(countryCode string, r *geoM
 	return nil
 }
 
-// GetAirportByQuery returns a slice of regions
+// GetAirportByQuery returns a slice of airports ordered by iata code
 func (repo *MongoRepository) GetAirportByQuery(q QueryAirport, a *[]geoModel.AirportV2) error {
 	s := repo.Session.Copy()
 	defer s.Close()
 
 	col := s.DB(repo.db).C(repo.airportTable)
 
+	dbQuery := airportsQuery(q)
+
+	if q.After != "" {
+		dbQuery["iata"] = mergeCondition(dbQuery["iata"], bson.M{"$gt": q.After})
+	}
+
+	query := col.Find(dbQuery).Sort("iata")
+
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	if len(q.Fields) > 0 {
+		query = query.Select(q.Fields)
+	}
+
+	err := query.All(a)
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return pkgErrors.ErrEntityNotFound
+		}
+
+		return fmt.Errorf("failed to get airport %w", err)
+	}
+
+	return nil
+}
+
+// CountAirports returns how many airports match the query, the pagination is ignored
+func (repo *MongoRepository) CountAirports(q QueryAirport) (int, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.airportTable)
+
+	count, err := col.Find(airportsQuery(q)).Count()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count airports %w", err)
+	}
+
+	return count, nil
+}
+
+func airportsQuery(q QueryAirport) bson.M {
 	dbQuery := bson.M{}
 
 	if len(q.IataCodes) > 0 {
@@ -330,17 +623,17 @@ func (repo *MongoRepository) GetAirportByQuery(q QueryAirport, a *[]geoModel.Air
 		dbQuery["countrycode"] = q.CountryCode
 	}
 
-	err := col.Find(dbQuery).All(a)
-
-	if err != nil {
-		if errors.Is(err, mgo.ErrNotFound) {
-			return pkgErrors.ErrEntityNotFound
+	if len(q.RegionIDs) > 0 {
+		dbQuery["region.id"] = bson.M{
+			"$in": q.RegionIDs,
 		}
+	}
 
-		return fmt.Errorf("failed to get airport %w", err)
+	if q.RegionType != "" {
+		dbQuery["region.regiontype"] = q.RegionType
 	}
 
-	return nil
+	return dbQuery
 }
 
 // GetIntersectedRegions finds all regions that intersects with a polygon
@@ -371,6 +664,34 @@ func (repo *MongoRepository) GetIntersectedRegions(geometry geoModel.Geometry, r
 	return nil
 }
 
+// GetRegionIDsWithin returns the geo ids of the regions of a type whose polygon is inside the geometry, up to limit
+func (repo *MongoRepository) GetRegionIDsWithin(geometry geoModel.Geometry, regionType geoModel.RegionType, limit int) ([]string, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
+
+	dbQuery := bson.M{
+		"bounding_polygon": bson.M{"$geoWithin": bson.M{"$geometry": geometry}},
+		"type":             regionType,
+	}
+
+	ids := make([]string, 0)
+
+	iter := col.Find(dbQuery).Select(bson.M{"geo_id": 1}).Limit(limit).Iter()
+
+	var r geoModel.GeoRegion
+	for iter.Next(&r) {
+		ids = append(ids, r.GeoID)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to get regions within polygon %w", err)
+	}
+
+	return ids, nil
+}
+
 // InsertAccommodation inserts an acommodation in the GeoRegion collection and adds all ancestor's relations
 func (repo *MongoRepository) InsertAccommodation(accommodation *geoModel.GeoRegion) ([]geoModel.GeoRegion, error) {
 	intersectedRegions := make([]geoModel.GeoRegion, 0)
@@ -383,6 +704,11 @@ func (repo *MongoRepository) InsertAccommodation(accommodation *geoModel.GeoRegi
 	}
 
 	for _, e := range intersectedRegions {
+		// Custom areas are not part of the curated hierarchy
+		if e.Type == geoModel.RegionTypeCustomArea {
+			continue
+		}
+
 		var r geoModel.Region
 		err1 := repo.GetRegionByTypeAndGeoID(e.Type, e.GeoID, &r)
 
@@ -419,6 +745,7 @@ func (repo *MongoRepository) SaveGeoRegion(r *geoModel.GeoRegion) error {
 	defer s.Close()
 
 	r.ID = bson.NewObjectId()
+	r.FillGeohash()
 	col := s.DB(repo.db).C(repo.geoCoordinatesTable)
 
 	if err := col.Insert(r); err != nil {
@@ -428,30 +755,111 @@ func (repo *MongoRepository) SaveGeoRegion(r *geoModel.GeoRegion) error {
 	return nil
 }
 
-// GetNearByRegions returns regions in a radius
-func (repo *MongoRepository) GetNearByRegions(latitude float64, longitude float64, regionTypes []geoModel.RegionType, radius float64) ([]geoModel.GeoRegion, error) {
+// GetNearByRegions returns the regions in a radius sorted by distance, with the geometry of the points only
+func (repo *MongoRepository) GetNearByRegions(q QueryNearby) ([]geoModel.NearbyRegion, error) {
 	s := repo.Session.Copy()
 	defer s.Close()
 
-	query := bson.M{"bounding_polygon": bson.M{
-		"$geoWithin": bson.M{
-			"$centerSphere": []interface{}{
-				[]interface{}{longitude, latitude}, radius / earthRadius,
-			},
-		},
-	}}
+	geoNear := bson.M{
+		"near":               geoModel.NewPointGeometry([]interface{}{q.Longitude, q.Latitude}),
+		"key":                "bounding_polygon",
+		"distanceField":      "distance_km",
+		"distanceMultiplier": 0.001,
+		"maxDistance":        q.Radius * 1000,
+		"spherical":          true,
+	}
+
+	if q.MinDistance > 0 {
+		geoNear["minDistance"] = q.MinDistance * 1000
+	}
 
-	regions := make([]geoModel.GeoRegion, 0)
+	if len(q.RegionTypes) > 0 {
+		geoNear["query"] = bson.M{"type": bson.M{"$in": q.RegionTypes}}
+	}
+
+	pipeline := []bson.M{{"$geoNear": geoNear}}
+
+	if q.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": q.Limit})
+	}
 
-	err := s.DB(repo.db).C(repo.geoCoordinatesTable).Find(query).All(&regions)
+	// the polygons are not sent, only the points that are the location of the region
+	pipeline = append(pipeline, bson.M{"$project": bson.M{
+		"geo_id":      1,
+		"type":        1,
+		"geohash":     1,
+		"distance_km": 1,
+		"bounding_polygon": bson.M{"$cond": []interface{}{
+			bson.M{"$eq": []interface{}{"$bounding_polygon.type", geoModel.GeometryPoint}}, "$bounding_polygon", "$$REMOVE",
+		}},
+	}})
+
+	regions := make([]geoModel.NearbyRegion, 0)
+
+	err := s.DB(repo.db).C(repo.geoCoordinatesTable).Pipe(pipeline).All(&regions)
 
 	if err != nil {
-		return []geoModel.GeoRegion{}, nil
+		return nil, fmt.Errorf("failed to get nearby regions %w", err)
+	}
+
+	for i := range regions {
+		regions[i].MapCoordinates()
 	}
 
 	return regions, nil
 }
 
+// AddRegionAliases adds aliases to a region, the existing ones are kept
+func (repo *MongoRepository) AddRegionAliases(regionType geoModel.RegionType, geoID string, aliases map[geoModel.Language][]string) error {
+	addToSet := bson.M{}
+
+	for l, a := range aliases {
+		addToSet["aliases."+string(l)] = bson.M{"$each": a}
+	}
+
+	if len(addToSet) == 0 {
+		return nil
+	}
+
+	return repo.updateRegionAliases(regionType, geoID, bson.M{"$addToSet": addToSet})
+}
+
+// SetRegionAliases replaces all the aliases of a region
+func (repo *MongoRepository) SetRegionAliases(regionType geoModel.RegionType, geoID string, aliases map[geoModel.Language][]string) error {
+	return repo.updateRegionAliases(regionType, geoID, bson.M{"$set": bson.M{"aliases": aliases}})
+}
+
+// RemoveRegionAlias removes an alias of a region in a language
+func (repo *MongoRepository) RemoveRegionAlias(regionType geoModel.RegionType, geoID string, language geoModel.Language, alias string) error {
+	return repo.updateRegionAliases(regionType, geoID, bson.M{"$pull": bson.M{"aliases." + string(language): alias}})
+}
+
+// updateRegionAliases applies the update and sets the alias names of the updated aliases
+func (repo *MongoRepository) updateRegionAliases(regionType geoModel.RegionType, geoID string, update bson.M) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(string(regionType))
+
+	var region geoModel.Region
+
+	_, err := col.Find(bson.M{"geo_id": geoID}).Select(bson.M{"aliases": 1}).Apply(mgo.Change{Update: update, ReturnNew: true}, &region)
+
+	if err == nil {
+		err = col.UpdateId(region.ID, bson.M{"$set": bson.M{"alias_names": geoModel.AliasNames(region.Aliases)}})
+	}
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return fmt.Errorf("failed to update aliases of region %s %s. %w", regionType, geoID, pkgErrors.ErrEntityNotFound)
+		}
+
+		return fmt.Errorf("failed to update aliases of region %s %s. %w", regionType, geoID, err)
+	}
+
+	return nil
+}
+
 func (repo *MongoRepository) CheckIfRepositoryIsActive() bool {
 	if err := repo.Session.Ping(); err != nil {
 		return false
@@ -279,6 +279,47 @@ func (r *MongoRepositoryV1) FindCountryByID(id string) (*model.Country, error) {
 	return country, nil
 }
 
+// FindGeoIDs returns the geo id of each entity of the type in one query, the ids not found are missing in the result
+func (r *MongoRepositoryV1) FindGeoIDs(entityType model.GeoEntityType, ids []bson.ObjectId) (map[bson.ObjectId]string, error) {
+	var collection string
+
+	switch entityType {
+	case model.GeoEntityTypeCountry:
+		collection = conf.GetProps().Mongo.CountryTable
+	case model.GeoEntityTypeCity:
+		collection = conf.GetProps().Mongo.CitiesTable
+	case model.GeoEntityTypeState:
+		collection = conf.GetProps().Mongo.StatesTable
+	case model.GeoEntityTypeNeighbourhood:
+		collection = conf.GetProps().Mongo.NeighbourhoodsTable
+	default:
+		return nil, fmt.Errorf("geo entity type %s has no geo id", entityType)
+	}
+
+	s := r.Session.Copy()
+	defer s.Close()
+
+	var entities []struct {
+		ID    bson.ObjectId `bson:"_id"`
+		GeoID string        `bson:"geo_id"`
+	}
+
+	col := s.DB(conf.GetProps().Mongo.DBV1).C(collection)
+	err := col.Find(bson.M{"_id": bson.M{"$in": ids}}).Select(bson.M{"geo_id": 1}).All(&entities)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geo ids %w", err)
+	}
+
+	result := make(map[bson.ObjectId]string, len(entities))
+
+	for _, e := range entities {
+		result[e.ID] = e.GeoID
+	}
+
+	return result, nil
+}
+
 func (r *MongoRepositoryV1) findEntityByID(id string, collection string, target interface{}) error {
 	err := validateObjectID(id)
 
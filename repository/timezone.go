@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	geoModel "github.com/basset-la/api-geo/model"
+	log "github.com/sirupsen/logrus"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetTimezone returns the IANA zone of the timezone polygon containing the coordinates
+func (repo *MongoRepository) GetTimezone(latitude float64, longitude float64) (string, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.timezonesTable)
+
+	point := geoModel.NewPointGeometry([]interface{}{longitude, latitude})
+
+	var tz geoModel.TimezoneRegion
+
+	err := col.Find(bson.M{
+		"geometry": bson.M{"$geoIntersects": bson.M{"$geometry": point}},
+	}).Select(bson.M{"tzid": 1}).One(&tz)
+
+	if err != nil {
+		if errors.Is(err, mgo.ErrNotFound) {
+			return "", fmt.Errorf("timezone of %f,%f not found. %w", latitude, longitude, pkgErrors.ErrEntityNotFound)
+		}
+
+		return "", fmt.Errorf("failed to get timezone %w", err)
+	}
+
+	return tz.Zone, nil
+}
+
+// SaveTimezoneRegion inserts or replaces the polygon of a timezone
+func (repo *MongoRepository) SaveTimezoneRegion(tz *geoModel.TimezoneRegion) error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.timezonesTable)
+
+	if _, err := col.Upsert(bson.M{"tzid": tz.Zone}, bson.M{"$set": bson.M{"tzid": tz.Zone, "geometry": tz.Geometry}}); err != nil {
+		return fmt.Errorf("failed to save timezone %s. %w", tz.Zone, err)
+	}
+
+	return nil
+}
+
+// EnsureTimezoneIndex creates the geospatial index used to resolve timezones
+func (repo *MongoRepository) EnsureTimezoneIndex() error {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	col := s.DB(repo.db).C(repo.timezonesTable)
+
+	if err := col.EnsureIndex(mgo.Index{Key: []string{"$2dsphere:geometry"}}); err != nil {
+		return fmt.Errorf("failed to create timezone index. %w", err)
+	}
+
+	return nil
+}
+
+// BackfillTimezones sets the timezone of every region and airport without one, it returns how many were updated
+func (repo *MongoRepository) BackfillTimezones(regionTypes []geoModel.RegionType) (int, error) {
+	s := repo.Session.Copy()
+	defer s.Close()
+
+	updated := 0
+	missing := bson.M{"timezone": bson.M{"$exists": false}}
+
+	for _, regionType := range regionTypes {
+		col := s.DB(repo.db).C(string(regionType))
+		iter := col.Find(missing).Select(bson.M{"geo_id": 1, "coordinates": 1}).Iter()
+
+		var r geoModel.Region
+		for iter.Next(&r) {
+			zone, ok := repo.timezoneOf(r.Center.Latitude, r.Center.Longitude)
+			if ok {
+				if err := col.Update(bson.M{"geo_id": r.GeoID}, bson.M{"$set": bson.M{"timezone": zone}}); err != nil {
+					log.Error(fmt.Errorf("failed to set timezone of %s %s. %w", regionType, r.GeoID, err))
+				} else {
+					updated++
+				}
+			}
+
+			r = geoModel.Region{}
+		}
+
+		if err := iter.Close(); err != nil {
+			return updated, fmt.Errorf("failed to backfill timezones of %s. %w", regionType, err)
+		}
+	}
+
+	col := s.DB(repo.db).C(repo.airportTable)
+	iter := col.Find(missing).Select(bson.M{"iata": 1, "coordinates": 1}).Iter()
+
+	var a geoModel.AirportV2
+	for iter.Next(&a) {
+		zone, ok := repo.timezoneOf(a.Coordinates.Latitude, a.Coordinates.Longitude)
+		if ok {
+			if err := col.Update(bson.M{"iata": a.IataCode}, bson.M{"$set": bson.M{"timezone": zone}}); err != nil {
+				log.Error(fmt.Errorf("failed to set timezone of airport %s. %w", a.IataCode, err))
+			} else {
+				updated++
+			}
+		}
+
+		a = geoModel.AirportV2{}
+	}
+
+	if err := iter.Close(); err != nil {
+		return updated, fmt.Errorf("failed to backfill timezones of airports. %w", err)
+	}
+
+	return updated, nil
+}
+
+func (repo *MongoRepository) fillRegionTimezone(r *geoModel.Region) {
+	if r.Timezone != "" {
+		return
+	}
+
+	if zone, ok := repo.timezoneOf(r.Center.Latitude, r.Center.Longitude); ok {
+		r.Timezone = zone
+	}
+}
+
+func (repo *MongoRepository) fillAirportTimezone(a *geoModel.AirportV2) {
+	if a.Timezone != "" {
+		return
+	}
+
+	if zone, ok := repo.timezoneOf(a.Coordinates.Latitude, a.Coordinates.Longitude); ok {
+		a.Timezone = zone
+	}
+}
+
+// timezoneOf resolves a timezone without failing, a missing timezone must never block a write
+func (repo *MongoRepository) timezoneOf(latitude float64, longitude float64) (string, bool) {
+	if latitude == 0 && longitude == 0 {
+		return "", false
+	}
+
+	zone, err := repo.GetTimezone(latitude, longitude)
+	if err != nil {
+		if !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			log.Error(err)
+		}
+
+		return "", false
+	}
+
+	return zone, true
+}
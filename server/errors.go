@@ -0,0 +1,55 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/utils/v4/api"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	log "github.com/sirupsen/logrus"
+)
+
+// errorJSON is the response of an error with the status of its code, the body is the catalogue error
+// so that clients can branch on its code. Internal errors are noticed and their cause is not sent.
+func errorJSON(r *http.Request, err error) *api.Response {
+	e := pkgErrors.From(err)
+
+	notice(r, e, err)
+
+	return api.DataJSON(e.Status(), e, nil)
+}
+
+// badRequest is the response of an invalid parameter or body
+func badRequest(err error) *api.Response {
+	e := pkgErrors.BadRequest(err)
+
+	return api.DataJSON(e.Status(), e, nil)
+}
+
+// unprocessable is the response of a valid request that can not be applied, e.g. an invalid geometry
+func unprocessable(err error) *api.Response {
+	e := pkgErrors.Unprocessable(err)
+
+	return api.DataJSON(e.Status(), e, nil)
+}
+
+// writeError writes the error response of the handlers that write their own body
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	e := pkgErrors.From(err)
+
+	notice(r, e, err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Status())
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+func notice(r *http.Request, e *pkgErrors.Error, err error) {
+	if e.Status() < http.StatusInternalServerError {
+		return
+	}
+
+	newrelic.FromContext(r.Context()).NoticeError(err)
+	log.Error(err)
+}
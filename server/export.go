@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/export"
+	"github.com/basset-la/api-geo/model"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	log "github.com/sirupsen/logrus"
+)
+
+// exportPath is served next to the api router because its responses are streamed instead of buffered
+const exportPath = "/v2/export"
+
+// exportFlushEvery is how many regions are written between flushes of the response
+const exportFlushEvery = 500
+
+// exportHandler streams the regions of the types param as ndjson, csv or geojson.
+// The csv names are in the lang param languages, or in the fallback ones.
+func exportHandler(w http.ResponseWriter, r *http.Request) {
+	txn := newrelic.FromContext(r.Context())
+
+	if r.Method != http.MethodGet {
+		writeError(w, r, pkgErrors.New(pkgErrors.CodeMethodNotAllowed, "method not allowed"))
+
+		return
+	}
+
+	qp := r.URL.Query()
+
+	if qp.Get("types") == "" {
+		writeError(w, r, pkgErrors.New(pkgErrors.CodeMissingParameter, "is required").WithField("types"))
+
+		return
+	}
+
+	regionTypes := make([]model.RegionType, 0)
+
+	for _, t := range strings.Split(qp.Get("types"), ",") {
+		rt := model.RegionType(strings.TrimSpace(t))
+
+		if !rt.IsValid() {
+			writeError(w, r, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", rt).WithField("types"))
+
+			return
+		}
+
+		regionTypes = append(regionTypes, rt)
+	}
+
+	var polygons bool
+
+	if qsPolygons := qp.Get("polygons"); qsPolygons != "" {
+		var err error
+
+		if polygons, err = strconv.ParseBool(qsPolygons); err != nil {
+			writeError(w, r, pkgErrors.Invalid("polygons", "must be true or false"))
+
+			return
+		}
+	}
+
+	format := export.Format(qp.Get("format"))
+
+	if format == "" {
+		format = export.FormatNDJSON
+	}
+
+	languages := requestedLanguages(r)
+
+	if len(languages) == 0 {
+		languages = fallbackLanguages()
+	}
+
+	writer, err := export.NewWriter(format, w, languages)
+
+	if err != nil {
+		writeError(w, r, pkgErrors.BadRequest(err))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	count := 0
+
+	err = env.geoRepository.ExportRegions(regionTypes, polygons, func(region *model.RegionExport) error {
+		if err := writer.Write(region); err != nil {
+			return err
+		}
+
+		count++
+
+		if flusher != nil && count%exportFlushEvery == 0 {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		// The status was already sent, the client sees a truncated body
+		txn.NoticeError(err)
+		log.Error(fmt.Errorf("export failed after %d regions. %w", count, err))
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/utils/v4/api"
+)
+
+// Fields always returned so the client can identify every element and paginate
+var (
+	regionKeyFields  = []string{"id"}
+	airportKeyFields = []string{"iata_code"}
+)
+
+// fieldsParam reads the fields projection, basic=true is kept as an alias of fields=id,name
+func fieldsParam(qp url.Values) (*model.Projection, error) {
+	if basicQuery := qp.Get("basic"); len(basicQuery) > 0 {
+		basic, err := strconv.ParseBool(basicQuery)
+
+		if err != nil {
+			return nil, pkgErrors.Invalid("basic", "must be true or false")
+		}
+
+		if basic {
+			if qp.Get("fields") != "" {
+				return nil, pkgErrors.Invalid("basic", "and [fields] cannot be used together")
+			}
+
+			return &model.Projection{Fields: []string{"id", "name"}}, nil
+		}
+	}
+
+	return model.ParseProjection(qp.Get("fields"))
+}
+
+// projected localizes the data and removes the fields not selected by the projection
+func projected(r *http.Request, data interface{}, p *model.Projection, keep []string, headers map[string]string) *api.Response {
+	result, err := p.Apply(localized(r, data), keep...)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, result, headers)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/graph"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	log "github.com/sirupsen/logrus"
+)
+
+// graphqlPath is served next to the api router because GraphQL responses carry their own data and errors envelope
+const graphqlPath = "/v2/graphql"
+
+// graphqlHandler runs a GraphQL query from a JSON body, or from the query param of a GET.
+// Names are in the languages of the request unless a field asks for one.
+func graphqlHandler(w http.ResponseWriter, r *http.Request) {
+	txn := newrelic.FromContext(r.Context())
+
+	var req graph.Request
+
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeError(w, r, pkgErrors.Invalid("variables", "must be a JSON object"))
+
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, pkgErrors.New(pkgErrors.CodeInvalidBody, "invalid request body"))
+
+			return
+		}
+	default:
+		writeError(w, r, pkgErrors.New(pkgErrors.CodeMethodNotAllowed, "method not allowed"))
+
+		return
+	}
+
+	if req.Query == "" {
+		writeError(w, r, pkgErrors.New(pkgErrors.CodeMissingParameter, "is required").WithField("query"))
+
+		return
+	}
+
+	result := env.graph.Execute(r.Context(), req, requestedLanguages(r))
+
+	for _, err := range result.Errors {
+		if original := err.OriginalError(); original != nil {
+			log.Warnf("graphql query failed. %s", original)
+
+			continue
+		}
+
+		log.Warnf("graphql query failed. %s", err.Message)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		txn.NoticeError(err)
+	}
+}
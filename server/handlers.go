@@ -7,15 +7,16 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/diff"
 	pkgErrors "github.com/basset-la/api-geo/errors"
 	"github.com/basset-la/api-geo/model"
 	"github.com/basset-la/api-geo/repository"
 	"github.com/basset-la/utils/v4/api"
 	"github.com/gorilla/mux"
 	"github.com/newrelic/go-agent/v3/newrelic"
-	log "github.com/sirupsen/logrus"
 )
 
 // healthCheckHandler godoc
@@ -40,40 +41,77 @@ func healthCheckHandler(r *http.Request) *api.Response {
 	return api.DataJSON(http.StatusOK, map[string]string{"version": conf.Version, "mongo-db": mongoDBConection}, nil)
 }
 
-func getRegionByTypeAndID(regionType model.RegionType, r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+// shadowReadStatsHandler godoc
+// @Summary Shadow read stats
+// @Description Returns the comparisons between V1 answers and their V2 equivalent
+// @Produce json
+// @Tags Internal
+// @Success 200 {object} map[string]service.ShadowStats
+// @Router /shadow-read [get]
+func shadowReadStatsHandler(r *http.Request) *api.Response {
+	return api.DataJSON(http.StatusOK, map[string]interface{}{
+		"enabled": env.shadowReader.Enabled(),
+		"stats":   env.shadowReader.Stats(),
+	}, nil)
+}
 
+func getRegionByTypeAndID(regionType model.RegionType, r *http.Request) *api.Response {
 	id := mux.Vars(r)["id"]
 
+	projection, err := fieldsParam(r.URL.Query())
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if projection != nil {
+		return getProjectedRegionByTypeAndID(regionType, id, projection, r)
+	}
+
 	var region model.Region
 
-	err := env.geoRepository.GetRegionByTypeAndGeoID(regionType, id, &region)
+	err = env.geoRepository.GetRegionByTypeAndGeoID(regionType, id, &region)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("%s not found", regionType), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
 		}
 
-		txn.NoticeError(err)
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, localized(r, region), nil)
+}
+
+func getProjectedRegionByTypeAndID(regionType model.RegionType, id string, projection *model.Projection, r *http.Request) *api.Response {
+	fields, err := projection.BSON(model.Region{}, regionKeyFields...)
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if err != nil {
+		return badRequest(err)
 	}
 
-	return api.DataJSON(http.StatusOK, region, nil)
+	regions := make([]model.Region, 0, 1)
+
+	err = env.geoRepository.GetRegions(repository.QueryRegion{RegionType: regionType, GeoIDs: []string{id}, Limit: 1, Fields: fields}, &regions)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	if len(regions) == 0 {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
+	}
+
+	return projected(r, regions[0], projection, regionKeyFields, nil)
 }
 
 func getRegionsByQuery(regionType model.RegionType, r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	qp := r.URL.Query()
 
 	var err error
 
 	qpGeoIDs := qp.Get("ids")
 	qpDesc := qp.Get("descendants")
-	basicQuery := qp.Get("basic")
-	qspage := qp.Get("page")
-	qslimit := qp.Get("limit")
 
 	var geoIds []string
 
@@ -87,28 +125,49 @@ func getRegionsByQuery(regionType model.RegionType, r *http.Request) *api.Respon
 		descendants = strings.Split(qpDesc, ",")
 	}
 
-	q, err := checkQueryParams(basicQuery, qslimit, qspage)
+	projection, err := fieldsParam(qp)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	fields, err := projection.BSON(model.Region{}, regionKeyFields...)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	q := &repository.QueryRegion{Fields: fields}
+
+	page, err := parsePagination(qp)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return badRequest(err)
 	}
 
+	q.Page = page.Page
+	q.Limit = page.Limit
+	q.After = page.After
+
 	q.GeoIDs = geoIds
 	q.RegionType = regionType
 	q.Descendants = descendants
 
+	if name := qp.Get("name"); name != "" {
+		q.Name = name
+		q.NameLanguages = model.LanguageChain(requestedLanguages(r), fallbackLanguages())
+	}
+
 	if len(qp.Get("country_code")) > 0 && regionType != model.RegionTypeCountry {
 		country := model.Region{}
 		err = env.geoRepository.GetCountryByCountryCode(qp.Get("country_code"), &country)
 
 		if err != nil {
 			if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-				return api.ErrJSON(http.StatusNotFound, fmt.Errorf("%s not found", model.RegionTypeCountry), nil)
+				return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", model.RegionTypeCountry))
 			}
 
-			txn.NoticeError(err)
-
-			return api.ErrJSON(http.StatusInternalServerError, err, nil)
+			return errorJSON(r, err)
 		}
 
 		q.Ancestors = []string{country.GeoID}
@@ -117,69 +176,136 @@ func getRegionsByQuery(regionType model.RegionType, r *http.Request) *api.Respon
 		q.CountryCode = qp.Get("country_code")
 	}
 
-	return getRegionsQueryResponse(q, regionType, txn)
+	if resp := setRegionAreaFilters(q, page, r); resp != nil {
+		return resp
+	}
+
+	return getRegionsQueryResponse(q, page, projection, regionType, r)
 }
 
-func checkQueryParams(basicQuery, qslimit, qspage string) (*repository.QueryRegion, error) {
-	basic := false
+// maxRegionsWithinPolygon bounds the ids of the within_polygon_of filter, they are sent to the region query in every page
+const maxRegionsWithinPolygon = 5000
 
-	var err error
+// setRegionAreaFilters reads the ancestor_id, ancestor_type, bbox and within_polygon_of filters.
+// When the polygon leaves no region to return it answers the empty page itself.
+func setRegionAreaFilters(q *repository.QueryRegion, page *pagination, r *http.Request) *api.Response {
+	qp := r.URL.Query()
 
-	if len(basicQuery) > 0 {
-		basic, err = strconv.ParseBool(basicQuery)
+	if ancestorType := model.RegionType(qp.Get("ancestor_type")); ancestorType != "" {
+		if !ancestorType.IsValid() {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", ancestorType).WithField("ancestor_type"))
+		}
 
-		if err != nil {
-			return nil, fmt.Errorf("[basic] must be true or false")
+		if qp.Get("ancestor_id") == "" {
+			return errorJSON(r, pkgErrors.Invalid("ancestor_type", "requires [ancestor_id]"))
 		}
+
+		q.AncestorType = ancestorType
 	}
 
-	var limit int
-	if len(qslimit) > 0 {
-		limit, err = strconv.Atoi(qslimit)
+	q.AncestorID = qp.Get("ancestor_id")
+
+	if bbox := qp.Get("bbox"); bbox != "" {
+		b, err := model.ParseBoundingBox(bbox)
 
 		if err != nil {
-			return nil, fmt.Errorf("[limit] must be a valid number")
+			return errorJSON(r, pkgErrors.Invalid("bbox", "%w", err))
 		}
+
+		q.BoundingBox = b
 	}
 
-	var page int
-	if len(qspage) > 0 {
-		page, err = strconv.Atoi(qspage)
+	if container := qp.Get("within_polygon_of"); container != "" {
+		var polygon model.GeoRegion
+
+		err := env.geoRepository.GetGeoRegion(container, &polygon)
+
+		if err != nil {
+			if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+				return errorJSON(r, pkgErrors.New(pkgErrors.CodePolygonNotFound, "polygon of %s not found", container))
+			}
+
+			return errorJSON(r, err)
+		}
+
+		if polygon.Geometry.Type != model.GeometryPolygon && polygon.Geometry.Type != model.GeometryMultiPolygon {
+			return errorJSON(r, pkgErrors.Invalid("within_polygon_of", "%s is not a polygon", container))
+		}
+
+		geometry := model.Geometry{Type: polygon.Geometry.Type, Coordinates: polygon.Geometry.Coordinates}
+		ids, err := env.geoRepository.GetRegionIDsWithin(geometry, q.RegionType, maxRegionsWithinPolygon+1)
 
 		if err != nil {
-			return nil, fmt.Errorf("[page] must be a valid number")
+			return errorJSON(r, err)
+		}
+
+		if len(ids) > maxRegionsWithinPolygon {
+			return errorJSON(r, pkgErrors.Invalid("within_polygon_of", "%s contains more than %d regions of type %s", container, maxRegionsWithinPolygon, q.RegionType).
+				WithDetail("max", maxRegionsWithinPolygon))
 		}
 
-		if page <= 0 {
-			return nil, fmt.Errorf("[page] must be a number greater than 0")
+		q.GeoIDs = intersectIDs(q.GeoIDs, ids)
+
+		if len(q.GeoIDs) == 0 {
+			return api.DataJSON(http.StatusOK, []model.Region{}, page.headers(r, 0, "", 0))
 		}
 	}
 
-	q := repository.QueryRegion{
-		Basic: basic,
-		Page:  page,
-		Limit: limit,
+	return nil
+}
+
+// intersectIDs keeps the ids present in both lists, an empty filter keeps all of them
+func intersectIDs(filter []string, ids []string) []string {
+	if len(filter) == 0 {
+		return ids
+	}
+
+	found := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		found[id] = true
+	}
+
+	result := make([]string, 0, len(filter))
+
+	for _, id := range filter {
+		if found[id] {
+			result = append(result, id)
+		}
 	}
 
-	return &q, nil
+	return result
 }
 
-func getRegionsQueryResponse(q *repository.QueryRegion, regionType model.RegionType, txn *newrelic.Transaction) *api.Response {
+func getRegionsQueryResponse(q *repository.QueryRegion, page *pagination, projection *model.Projection, regionType model.RegionType, r *http.Request) *api.Response {
 	regions := make([]model.Region, 0)
 
 	err := env.geoRepository.GetRegions(*q, &regions)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("%s not found", regionType), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	var total int
+
+	if page.Total {
+		total, err = env.geoRepository.Count(*q)
+
+		if err != nil {
+			return errorJSON(r, err)
 		}
+	}
 
-		txn.NoticeError(err)
+	var lastKey string
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if len(regions) > 0 {
+		lastKey = regions[len(regions)-1].GeoID
 	}
 
-	return api.DataJSON(http.StatusOK, regions, nil)
+	return projected(r, regions, projection, regionKeyFields, page.headers(r, len(regions), lastKey, total))
 }
 
 func getCountryByIDHandlerV2(r *http.Request) *api.Response {
@@ -223,352 +349,1177 @@ func getNeighborhoodByIDHandlerV2(r *http.Request) *api.Response {
 }
 
 func getAirportByIATACode(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	iataCode := mux.Vars(r)["iata_code"]
 
+	projection, err := fieldsParam(r.URL.Query())
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	fields, err := projection.BSON(model.AirportV2{}, airportKeyFields...)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if fields != nil {
+		airports := make([]model.AirportV2, 0, 1)
+
+		err = env.geoRepository.GetAirportByQuery(repository.QueryAirport{IataCodes: []string{iataCode}, Limit: 1, Fields: fields}, &airports)
+
+		if err != nil {
+			return errorJSON(r, err)
+		}
+
+		if len(airports) == 0 {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAirportNotFound, "airport not found"))
+		}
+
+		return projected(r, airports[0], projection, airportKeyFields, nil)
+	}
+
 	var airport model.AirportV2
 
-	err := env.geoRepository.GetAirportByIATACode(iataCode, &airport)
+	err = env.geoRepository.GetAirportByIATACode(iataCode, &airport)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAirportNotFound, "airport not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, localized(r, airport), nil)
+}
+
+// Get regions by query
+
+func getCountryByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeCountry, r)
+}
+
+func getCityByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeCity, r)
+}
+
+// func getHighLevelRegionByQueryHandlerV2(env *Env, r *http.Request, txn newrelic.Transaction) *api.Response {
+//	return getRegionsByQuery(RegionTypeHighLevelRegion, env, r, txn)
+//}
+
+func getContinentByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeContinent, r)
+}
+
+func getMultiCityVicinityByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeMultiCityVicinity, r)
+}
+
+func getTrainStationByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeTrainStation, r)
+}
+
+func getMetroStationByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeMetroStation, r)
+}
+
+func getProvinceStateByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeProvinceState, r)
+}
+
+func getPOIsByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypePOI, r)
+}
+
+func getNeighborhoodByQueryHandlerV2(r *http.Request) *api.Response {
+	return getRegionsByQuery(model.RegionTypeNeighborhood, r)
+}
+
+func getAirportByQuery(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	qpIataCodes := qp.Get("iata_codes")
+
+	var iataCodes []string
+
+	if len(qpIataCodes) > 0 {
+		iataCodes = strings.Split(qpIataCodes, ",")
+	}
+
+	page, err := parsePagination(qp)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if page.Page > 0 {
+		return errorJSON(r, pkgErrors.Invalid("page", "is not supported, use [cursor]"))
+	}
+
+	projection, err := fieldsParam(qp)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	fields, err := projection.BSON(model.AirportV2{}, airportKeyFields...)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	q := repository.QueryAirport{
+		CountryCode: qp.Get("country_code"),
+		IataCodes:   iataCodes,
+		Limit:       page.Limit,
+		After:       page.After,
+		Fields:      fields,
+	}
+
+	airports := make([]model.AirportV2, 0)
+
+	err = env.geoRepository.GetAirportByQuery(q, &airports)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAirportNotFound, "airport not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	var total int
+
+	if page.Total {
+		total, err = env.geoRepository.CountAirports(q)
+
+		if err != nil {
+			return errorJSON(r, err)
+		}
+	}
+
+	var lastKey string
+
+	if len(airports) > 0 {
+		lastKey = airports[len(airports)-1].IataCode
+	}
+
+	return projected(r, airports, projection, airportKeyFields, page.headers(r, len(airports), lastKey, total))
+}
+
+func getMetroAreaByCode(r *http.Request) *api.Response {
+	code := strings.ToUpper(mux.Vars(r)["code"])
+
+	var metro model.MetroArea
+
+	err := env.geoRepository.GetMetroAreaByCode(code, &metro)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeMetroAreaNotFound, "metro area not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, localized(r, metro), nil)
+}
+
+func getMetroAreasByQuery(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	q := repository.QueryMetroArea{
+		CountryCode: qp.Get("country_code"),
+		CityID:      qp.Get("city_id"),
+		Airport:     strings.ToUpper(qp.Get("airport")),
+	}
+
+	metros := make([]model.MetroArea, 0)
+
+	err := env.geoRepository.GetMetroAreas(q, &metros)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, localized(r, metros), nil)
+}
+
+func expandAirportCode(r *http.Request) *api.Response {
+	code := strings.ToUpper(mux.Vars(r)["code"])
+
+	if !model.IsIATACode(code) {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidIATACode, "%q must be three letters", code).WithField("code"))
+	}
+
+	cities, err := env.geoRepositoryV1.FindCities(repository.CityQuery{IataCode: code})
+
+	if err != nil && !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+		return errorJSON(r, err)
+	}
+
+	cityIDs := make([]string, 0, len(cities))
+
+	for _, c := range cities {
+		if c.GeoID != "" {
+			cityIDs = append(cityIDs, c.GeoID)
+		}
+	}
+
+	airports := make([]model.AirportV2, 0)
+
+	err = env.geoRepository.ExpandAirportCode(code, cityIDs, &airports)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAirportNotFound, "%s is not a metro area, city or airport code", code))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, localized(r, airports), nil)
+}
+
+func getIntersections(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	latitude, err := strconv.ParseFloat(qp.Get("latitude"), 64)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number. %w", err).WithField("latitude"))
+	}
+
+	longitude, err := strconv.ParseFloat(qp.Get("longitude"), 64)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number. %w", err).WithField("longitude"))
+	}
+
+	regionTypes := qp.Get("region_types")
+
+	var rts []model.RegionType
+
+	if len(regionTypes) > 0 {
+		for _, e := range strings.Split(regionTypes, ",") {
+			rts = append(rts, model.RegionType(e))
+		}
+	}
+
+	regions := make([]model.GeoRegion, 0)
+
+	err = env.geoRepository.GetIntersectedRegions(*model.NewPointGeometry([]interface{}{longitude, latitude}), rts, &regions)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return badRequest(err)
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, regions, nil)
+}
+
+func getTimezone(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	latitude, err := strconv.ParseFloat(qp.Get("latitude"), 64)
+
+	if err != nil || latitude < -90 || latitude > 90 {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number between -90 and 90").WithField("latitude"))
+	}
+
+	longitude, err := strconv.ParseFloat(qp.Get("longitude"), 64)
+
+	if err != nil || longitude < -180 || longitude > 180 {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number between -180 and 180").WithField("longitude"))
+	}
+
+	date := time.Now()
+
+	if qsDate := qp.Get("date"); qsDate != "" {
+		date, err = time.Parse(time.RFC3339, qsDate)
+
+		if err != nil {
+			date, err = time.Parse("2006-01-02", qsDate)
+		}
+
+		if err != nil {
+			return errorJSON(r, pkgErrors.Invalid("date", "must be a RFC3339 date time or a YYYY-MM-DD date"))
+		}
+	}
+
+	zone, err := env.geoRepository.GetTimezone(latitude, longitude)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeTimezoneNotFound, "timezone not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	tz, err := model.NewTimezone(zone, date)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, tz, nil)
+}
+
+func insertAccommodation(r *http.Request) *api.Response {
+	var accommodation model.GeoRegion
+
+	decoder := json.NewDecoder(r.Body)
+
+	err := decoder.Decode(&accommodation)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	intersectedRegions, err := env.geoRepository.InsertAccommodation(&accommodation)
+
+	if err != nil {
+		return errorJSON(r, fmt.Errorf("failed to insert accommodation id: %s. %w", accommodation.GeoID, err))
+	}
+
+	return api.DataJSON(http.StatusOK, intersectedRegions, nil)
+}
+
+func getNearbyRegions(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	latitude, err := strconv.ParseFloat(qp.Get("latitude"), 64)
+
+	if err != nil || latitude < -90 || latitude > 90 {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number between -90 and 90").WithField("latitude"))
+	}
+
+	longitude, err := strconv.ParseFloat(qp.Get("longitude"), 64)
+
+	if err != nil || longitude < -180 || longitude > 180 {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number between -180 and 180").WithField("longitude"))
+	}
+
+	radius, err := strconv.ParseFloat(qp.Get("radius"), 64)
+
+	if err != nil || radius <= 0 {
+		return errorJSON(r, pkgErrors.Invalid("radius", "must be a positive number of kilometers"))
+	}
+
+	q := repository.QueryNearby{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Radius:    radius,
+	}
+
+	if qsMin := qp.Get("min_distance"); qsMin != "" {
+		q.MinDistance, err = strconv.ParseFloat(qsMin, 64)
+
+		if err != nil || q.MinDistance < 0 || q.MinDistance >= radius {
+			return errorJSON(r, pkgErrors.Invalid("min_distance", "must be a number of kilometers lower than the radius"))
+		}
+	}
+
+	defaultLimit, maxLimit := pageLimits()
+	q.Limit = defaultLimit
+
+	if qsLimit := qp.Get("limit"); qsLimit != "" {
+		q.Limit, err = strconv.Atoi(qsLimit)
+
+		if err != nil || q.Limit < 1 || q.Limit > maxLimit {
+			return errorJSON(r, pkgErrors.Invalid("limit", "must be a number between 1 and %d", maxLimit).WithDetail("max", maxLimit))
+		}
+	}
+
+	if types := qp.Get("types"); types != "" {
+		for _, e := range strings.Split(types, ",") {
+			rt := model.RegionType(strings.TrimSpace(e))
+
+			if !rt.IsValid() && rt != model.RegionTypeAccommodation {
+				return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", rt).WithField("types"))
+			}
+
+			q.RegionTypes = append(q.RegionTypes, rt)
+		}
+	}
+
+	regions, err := env.geoRepository.GetNearByRegions(q)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, regions, nil)
+}
+
+func getGeoRegion(r *http.Request) *api.Response {
+	id := mux.Vars(r)["id"]
+
+	var region model.GeoRegion
+
+	err := env.geoRepository.GetGeoRegion(id, &region)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodePolygonNotFound, "polygon not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, region, nil)
+}
+
+func saveRegion(r *http.Request) *api.Response {
+	var region model.Region
+	err := json.NewDecoder(r.Body).Decode(&region)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	if err = validateRegion(&region); err != nil {
+		return errorJSON(r, err)
+	}
+
+	err = env.geoRepository.SaveRegion(&region)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, region, nil)
+}
+
+func updateRegion(r *http.Request) *api.Response {
+	var region model.Region
+
+	err := json.NewDecoder(r.Body).Decode(&region)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	if err = validateRegion(&region); err != nil {
+		return errorJSON(r, err)
+	}
+
+	err = env.geoRepository.UpdateRegion(&region)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, region, nil)
+}
+
+func regionTypeParam(r *http.Request) (model.RegionType, error) {
+	regionType := model.RegionType(mux.Vars(r)["type"])
+
+	if !regionType.IsValid() {
+		return "", pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", regionType).WithField("type")
+	}
+
+	return regionType, nil
+}
+
+// clusterSampleSize is the default number of ids returned with each cluster
+const clusterSampleSize = 10
+
+// maxClusterCells bounds the grid of a clusters request, a 4K screen at the cell size of its zoom fits in it
+const maxClusterCells = 4096
+
+func getAccommodationClusters(r *http.Request) *api.Response {
+	qp := r.URL.Query()
+
+	bbox, err := model.ParseBoundingBox(qp.Get("bbox"))
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.Invalid("bbox", "%w", err))
+	}
+
+	zoom, err := strconv.Atoi(qp.Get("zoom"))
+
+	if err != nil || zoom < model.MinZoom || zoom > model.MaxZoom {
+		return errorJSON(r, pkgErrors.Invalid("zoom", "must be a number between %d and %d", model.MinZoom, model.MaxZoom).WithDetail("min", model.MinZoom).WithDetail("max", model.MaxZoom))
+	}
+
+	cellSize := model.ClusterCellSize(zoom)
+
+	if cells := model.ClusterCells(*bbox, cellSize); cells > maxClusterCells {
+		return errorJSON(r, pkgErrors.Invalid("bbox", "covers %d cells at zoom %d, more than %d", cells, zoom, maxClusterCells).WithDetail("max", maxClusterCells))
+	}
+
+	q := repository.QueryClusters{
+		BoundingBox: *bbox,
+		CellSize:    cellSize,
+		Sample:      clusterSampleSize,
+		Limit:       maxPageLimit,
+	}
+
+	if qsSample := qp.Get("sample"); qsSample != "" {
+		q.Sample, err = strconv.Atoi(qsSample)
+
+		if err != nil || q.Sample < 1 || q.Sample > maxPageLimit {
+			return errorJSON(r, pkgErrors.Invalid("sample", "must be a number between 1 and %d", maxPageLimit).WithDetail("max", maxPageLimit))
+		}
+	}
+
+	clusters, err := env.geoRepository.GetAccommodationClusters(q)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, clusters, nil)
+}
+
+func getGeohash(r *http.Request) *api.Response {
+	cell, err := model.NewGeohashCell(mux.Vars(r)["prefix"])
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.Invalid("prefix", "%w", err))
+	}
+
+	page, err := parsePagination(r.URL.Query())
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	regions, airports, err := env.geoRepository.GetByGeohash(cell.Geohash, page.Limit)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	result := model.GeohashEntities{GeohashCell: *cell, Regions: regions, Airports: airports}
+
+	return api.DataJSON(http.StatusOK, localized(r, result), nil)
+}
+
+func getRegionAccommodations(r *http.Request) *api.Response {
+	regionType, err := regionTypeParam(r)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	qp := r.URL.Query()
+
+	page, err := parsePagination(qp)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sortBy := qp.Get("sort")
+
+	if sortBy != "" && sortBy != "id" && sortBy != "distance" {
+		return errorJSON(r, pkgErrors.Invalid("sort", "must be id or distance"))
+	}
+
+	if sortBy == "distance" && page.After != "" {
+		return errorJSON(r, pkgErrors.Invalid("cursor", "is not supported sorting by distance, use [page]"))
+	}
+
+	id := mux.Vars(r)["id"]
+
+	var region model.Region
+
+	err = env.geoRepository.GetRegionByTypeAndGeoID(regionType, id, &region)
+
+	var polygon model.GeoRegion
+
+	if err == nil {
+		err = env.geoRepository.GetGeoRegion(id, &polygon)
+	}
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "region not found"))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	if polygon.Geometry.Type != model.GeometryPolygon && polygon.Geometry.Type != model.GeometryMultiPolygon {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeMissingPolygon, "region %s has no polygon", id))
+	}
+
+	q := repository.QueryAccommodations{
+		Within:         polygon.Geometry,
+		Center:         region.Center,
+		SortByDistance: sortBy == "distance",
+		Limit:          page.Limit,
+		After:          page.After,
+	}
+
+	if page.Page > 0 {
+		q.Skip = (page.Page - 1) * page.Limit
+	}
+
+	return regionAccommodations(r, q, page)
+}
+
+func regionAccommodations(r *http.Request, q repository.QueryAccommodations, page *pagination) *api.Response {
+	accommodations, err := env.geoRepository.GetAccommodationsWithin(q)
+
+	if err != nil {
+		return errorJSON(r, err)
+	}
+
+	var total int
+
+	if page.Total {
+		total, err = env.geoRepository.CountAccommodationsWithin(q)
+
+		if err != nil {
+			return errorJSON(r, err)
+		}
+	}
+
+	var lastKey string
+
+	// Pages sorted by distance are requested by number, they have no cursor to link
+	if len(accommodations) > 0 && !q.SortByDistance {
+		lastKey = accommodations[len(accommodations)-1].GeoID
+	}
+
+	return api.DataJSON(http.StatusOK, accommodations, page.headers(r, len(accommodations), lastKey, total))
+}
+
+func getRegionAliases(r *http.Request) *api.Response {
+	regionType, err := regionTypeParam(r)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	var region model.Region
+
+	err = env.geoRepository.GetRegionByTypeAndGeoID(regionType, mux.Vars(r)["id"], &region)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	if region.Aliases == nil {
+		region.Aliases = map[model.Language][]string{}
+	}
+
+	return api.DataJSON(http.StatusOK, region.Aliases, nil)
+}
+
+func addRegionAliases(r *http.Request) *api.Response {
+	return writeRegionAliases(r, env.geoRepository.AddRegionAliases)
+}
+
+func replaceRegionAliases(r *http.Request) *api.Response {
+	return writeRegionAliases(r, env.geoRepository.SetRegionAliases)
+}
+
+func writeRegionAliases(r *http.Request, write func(model.RegionType, string, map[model.Language][]string) error) *api.Response {
+	regionType, err := regionTypeParam(r)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	var aliases map[model.Language][]string
+
+	err = json.NewDecoder(r.Body).Decode(&aliases)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	aliases = model.NormalizeAliases(aliases)
+
+	if err = model.ValidateAliases(aliases); err != nil {
+		return errorJSON(r, err)
+	}
+
+	err = write(regionType, mux.Vars(r)["id"], aliases)
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return getRegionAliases(r)
+}
+
+func deleteRegionAlias(r *http.Request) *api.Response {
+	regionType, err := regionTypeParam(r)
+
+	if err != nil {
+		return badRequest(err)
+	}
+
+	vars := mux.Vars(r)
+
+	err = env.geoRepository.RemoveRegionAlias(regionType, vars["id"], model.Language(vars["lang"]), vars["alias"])
+
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "%s not found", regionType))
+		}
+
+		return errorJSON(r, err)
+	}
+
+	return getRegionAliases(r)
+}
+
+func saveAirport(r *http.Request) *api.Response {
+	var airport model.AirportV2
+
+	err := json.NewDecoder(r.Body).Decode(&airport)
+
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
 
-	if err != nil {
-		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("airport not found"), nil)
-		}
+	if err = airport.Validate(); err != nil {
+		return errorJSON(r, err)
+	}
 
-		txn.NoticeError(err)
+	err = env.geoRepository.SaveAirport(&airport)
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if err != nil {
+		return errorJSON(r, err)
 	}
 
 	return api.DataJSON(http.StatusOK, airport, nil)
 }
 
-// Get regions by query
+func updateAirport(r *http.Request) *api.Response {
+	var airport model.AirportV2
 
-func getCountryByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeCountry, r)
-}
+	err := json.NewDecoder(r.Body).Decode(&airport)
 
-func getCityByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeCity, r)
-}
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
 
-// func getHighLevelRegionByQueryHandlerV2(env *Env, r *http.Request, txn newrelic.Transaction) *api.Response {
-//	return getRegionsByQuery(RegionTypeHighLevelRegion, env, r, txn)
-//}
+	if err = airport.Validate(); err != nil {
+		return errorJSON(r, err)
+	}
 
-func getContinentByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeContinent, r)
-}
+	err = env.geoRepository.UpdateAirport(&airport)
 
-func getMultiCityVicinityByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeMultiCityVicinity, r)
-}
+	if err != nil {
+		return errorJSON(r, err)
+	}
 
-func getTrainStationByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeTrainStation, r)
+	return api.DataJSON(http.StatusOK, airport, nil)
 }
 
-func getMetroStationByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeMetroStation, r)
-}
+func saveMetroArea(r *http.Request) *api.Response {
+	var metro model.MetroArea
 
-func getProvinceStateByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeProvinceState, r)
-}
+	err := json.NewDecoder(r.Body).Decode(&metro)
 
-func getPOIsByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypePOI, r)
-}
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
 
-func getNeighborhoodByQueryHandlerV2(r *http.Request) *api.Response {
-	return getRegionsByQuery(model.RegionTypeNeighborhood, r)
-}
+	if err = validateMetroArea(&metro); err != nil {
+		return errorJSON(r, err)
+	}
 
-func getAirportByQuery(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+	err = env.geoRepository.SaveMetroArea(&metro)
 
-	qp := r.URL.Query()
+	if err != nil {
+		return errorJSON(r, err)
+	}
 
-	qpIataCodes := qp.Get("iata_codes")
+	return api.DataJSON(http.StatusOK, metro, nil)
+}
 
-	var iataCodes []string
+func updateMetroArea(r *http.Request) *api.Response {
+	var metro model.MetroArea
 
-	if len(qpIataCodes) > 0 {
-		iataCodes = strings.Split(qpIataCodes, ",")
-	}
+	err := json.NewDecoder(r.Body).Decode(&metro)
 
-	q := repository.QueryAirport{
-		CountryCode: qp.Get("country_code"),
-		IataCodes:   iataCodes,
+	if err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
 	}
 
-	airports := make([]model.AirportV2, 0)
+	if err = validateMetroArea(&metro); err != nil {
+		return errorJSON(r, err)
+	}
 
-	err := env.geoRepository.GetAirportByQuery(q, &airports)
+	err = env.geoRepository.UpdateMetroArea(&metro)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("airport not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeMetroAreaNotFound, "metro area not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, airports, nil)
+	return api.DataJSON(http.StatusOK, metro, nil)
 }
 
-func getIntersections(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+func getPolygonMetrics(r *http.Request) *api.Response {
+	id := mux.Vars(r)["id"]
 
-	qp := r.URL.Query()
+	var region model.GeoRegion
 
-	latitude, err := strconv.ParseFloat(qp.Get("latitude"), 64)
+	err := env.geoRepository.GetGeoRegion(id, &region)
 
 	if err != nil {
-		txn.NoticeError(err)
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodePolygonNotFound, "polygon not found"))
+		}
 
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return errorJSON(r, err)
 	}
 
-	longitude, err := strconv.ParseFloat(qp.Get("longitude"), 64)
+	metrics, err := model.NewPolygonMetrics(region.Geometry)
 
 	if err != nil {
-		txn.NoticeError(err)
+		return unprocessable(err)
+	}
+
+	metrics.GeoID = region.GeoID
+
+	return api.DataJSON(http.StatusOK, metrics, nil)
+}
+
+func geometryOps(r *http.Request) *api.Response {
+	var req model.GeometryOpsRequest
 
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
 	}
 
-	regionTypes := qp.Get("region_types")
+	if req.Persist != nil {
+		if req.Persist.GeoID == "" || req.Persist.Type == "" {
+			return errorJSON(r, pkgErrors.Invalid("persist", "id and type are required"))
+		}
 
-	var rts []model.RegionType
+		if !req.Persist.Type.IsValid() {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", req.Persist.Type).WithField("persist.type"))
+		}
 
-	if len(regionTypes) > 0 {
-		rts = make([]model.RegionType, 0, len(regionTypes))
-		for i, e := range strings.Split(regionTypes, ",") {
-			rts[i] = model.RegionType(e)
+		var existing model.GeoRegion
+
+		err := env.geoRepository.GetGeoRegion(req.Persist.GeoID, &existing)
+
+		if err == nil {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodePolygonExists, "region %s already has a polygon", req.Persist.GeoID).WithField("persist.id"))
+		}
+
+		if !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, err)
 		}
 	}
 
-	regions := make([]model.GeoRegion, 0)
+	geometries := make([]model.Geometry, 0, len(req.Operands))
 
-	err = env.geoRepository.GetIntersectedRegions(*model.NewPointGeometry([]interface{}{longitude, latitude}), rts, &regions)
+	for i, o := range req.Operands {
+		if o.Geometry != nil {
+			geometries = append(geometries, *o.Geometry)
 
-	if err != nil {
-		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusBadRequest, err, nil)
+			continue
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
-	}
+		if o.GeoID == "" {
+			return errorJSON(r, pkgErrors.Invalid("operands", "%d needs a geo_id or a geometry", i))
+		}
 
-	return api.DataJSON(http.StatusOK, regions, nil)
-}
+		var region model.GeoRegion
 
-func insertAccommodation(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+		if err := env.geoRepository.GetGeoRegion(o.GeoID, &region); err != nil {
+			if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+				return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "region %s not found", o.GeoID))
+			}
 
-	var accommodation model.GeoRegion
+			return errorJSON(r, err)
+		}
 
-	decoder := json.NewDecoder(r.Body)
+		geometries = append(geometries, region.Geometry)
+	}
 
-	err := decoder.Decode(&accommodation)
+	result, err := model.ApplyGeometryOperation(req.Operation, geometries, req.DistanceKm)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		return unprocessable(err)
 	}
 
-	intersectedRegions, err := env.geoRepository.InsertAccommodation(&accommodation)
+	if req.Persist == nil {
+		return api.DataJSON(http.StatusOK, result, nil)
+	}
 
-	if err != nil {
-		txn.NoticeError(err)
+	region := model.GeoRegion{
+		BaseRegion: model.BaseRegion{GeoID: req.Persist.GeoID, Type: req.Persist.Type},
+		Geometry:   *result,
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, fmt.Errorf("failed to insert accommodation id: %s", accommodation.GeoID), nil)
+	if err = env.geoRepository.SaveGeoRegion(&region); err != nil {
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, intersectedRegions, nil)
+	return api.DataJSON(http.StatusCreated, region, nil)
 }
 
-func getNearbyRegions(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+// Limits of the export uploaded to the diff, it is indexed in memory before the comparison
+const maxDiffBodyBytes = 512 << 20
 
-	qp := r.URL.Query()
-	longitude, _ := strconv.ParseFloat(qp.Get("longitude"), 64)
-	latitude, _ := strconv.ParseFloat(qp.Get("latitude"), 64)
-	radius, _ := strconv.ParseFloat(qp.Get("radius"), 64)
-	types := qp.Get("types")
+var diffLimits = diff.Limits{Regions: 1000000, Polygons: 200000}
+
+// diffDataset compares the NDJSON export in the body with the live regions of its types, or of the types param.
+// The polygons are only compared when the export has them.
+func diffDataset(r *http.Request) *api.Response {
+	var regionTypes []model.RegionType
 
-	rt := strings.Split(types, ",")
+	if types := r.URL.Query().Get("types"); types != "" {
+		for _, e := range strings.Split(types, ",") {
+			rt := model.RegionType(strings.TrimSpace(e))
 
-	regionTypes := make([]model.RegionType, 0, len(rt))
+			if !rt.IsValid() {
+				return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidRegionType, "%s is not a valid region type", rt).WithField("types"))
+			}
 
-	for i, e := range rt {
-		regionTypes[i] = model.RegionType(e)
+			regionTypes = append(regionTypes, rt)
+		}
 	}
 
-	regions, err := env.geoRepository.GetNearByRegions(latitude, longitude, regionTypes, radius)
+	after, err := diff.ReadSnapshot(http.MaxBytesReader(nil, r.Body, maxDiffBodyBytes), regionTypes, diffLimits)
 
 	if err != nil {
-		txn.NoticeError(err)
+		return badRequest(err)
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if len(regionTypes) == 0 {
+		regionTypes = after.Types()
 	}
 
-	return api.DataJSON(http.StatusOK, regions, nil)
+	before := diff.NewSnapshot(regionTypes)
+
+	if err = env.geoRepository.ExportRegions(regionTypes, after.HasPolygons(), before.Add); err != nil {
+		return errorJSON(r, err)
+	}
+
+	return api.DataJSON(http.StatusOK, diff.Compare(before, after), nil)
 }
 
-func getGeoRegion(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+// polygonCenter returns the center to write back into the region of the polygon
+// when the center query param is centroid or label_point
+func polygonCenter(r *http.Request, region *model.GeoRegion) (*model.Center, *api.Response) {
+	option := r.URL.Query().Get("center")
 
-	id := mux.Vars(r)["id"]
+	if option == "" {
+		return nil, nil
+	}
 
-	var region model.GeoRegion
+	if option != "centroid" && option != "label_point" {
+		return nil, errorJSON(r, pkgErrors.Invalid("center", "must be centroid or label_point"))
+	}
 
-	err := env.geoRepository.GetGeoRegion(id, &region)
+	metrics, err := model.NewPolygonMetrics(region.Geometry)
+
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	var existing model.Region
+
+	err = env.geoRepository.GetRegionByTypeAndGeoID(region.Type, region.GeoID, &existing)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("region not found"), nil)
+			return nil, errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "region not found"))
 		}
 
-		txn.NoticeError(err)
+		return nil, errorJSON(r, err)
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if option == "centroid" {
+		return &metrics.Centroid, nil
 	}
 
-	return api.DataJSON(http.StatusOK, region, nil)
+	return &metrics.LabelPoint, nil
 }
 
-func saveRegion(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+func getCustomArea(r *http.Request) *api.Response {
+	var area model.CustomArea
 
-	var region model.Region
-	err := json.NewDecoder(r.Body).Decode(&region)
+	err := env.geoRepository.GetCustomArea(mux.Vars(r)["id"], &area)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeCustomAreaNotFound, "custom area not found"))
+		}
+
+		return errorJSON(r, err)
 	}
 
-	err = env.geoRepository.SaveRegion(&region)
+	return api.DataJSON(http.StatusOK, localized(r, area), nil)
+}
 
-	if err != nil {
-		txn.NoticeError(err)
+func getCustomAreasByQuery(r *http.Request) *api.Response {
+	qp := r.URL.Query()
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	page, err := parsePagination(qp)
+
+	if err != nil {
+		return badRequest(err)
 	}
 
-	return api.DataJSON(http.StatusOK, region, nil)
-}
+	if page.Page > 0 || page.Total {
+		return errorJSON(r, pkgErrors.Invalid("page", "and [total] are not supported, use [cursor]"))
+	}
 
-func updateRegion(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+	q := repository.QueryCustomArea{
+		Owner:      qp.Get("owner"),
+		Tag:        qp.Get("tag"),
+		AncestorID: qp.Get("ancestor_id"),
+		Limit:      page.Limit,
+		After:      page.After,
+	}
 
-	var region model.Region
+	areas := make([]model.CustomArea, 0)
 
-	err := json.NewDecoder(r.Body).Decode(&region)
+	err = env.geoRepository.GetCustomAreas(q, &areas)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		return errorJSON(r, err)
 	}
 
-	err = env.geoRepository.UpdateRegion(&region)
-
-	if err != nil {
-		txn.NoticeError(err)
+	var lastKey string
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if len(areas) > 0 {
+		lastKey = areas[len(areas)-1].GeoID
 	}
 
-	return api.DataJSON(http.StatusOK, region, nil)
+	return api.DataJSON(http.StatusOK, localized(r, areas), page.headers(r, len(areas), lastKey, 0))
 }
 
-func saveAirport(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+func saveCustomArea(r *http.Request) *api.Response {
+	var area model.CustomArea
 
-	var airport model.AirportV2
+	if err := json.NewDecoder(r.Body).Decode(&area); err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
 
-	err := json.NewDecoder(r.Body).Decode(&airport)
+	if err := area.Validate(); err != nil {
+		return badRequest(err)
+	}
 
-	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+	if err := env.geoRepository.SaveCustomArea(&area); err != nil {
+		return errorJSON(r, err)
 	}
 
-	err = env.geoRepository.SaveAirport(&airport)
+	return api.DataJSON(http.StatusCreated, area, nil)
+}
 
-	if err != nil {
-		txn.NoticeError(err)
+func updateCustomArea(r *http.Request) *api.Response {
+	var area model.CustomArea
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if err := json.NewDecoder(r.Body).Decode(&area); err != nil {
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
 	}
 
-	return api.DataJSON(http.StatusOK, airport, nil)
-}
-
-func updateAirport(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
+	area.GeoID = mux.Vars(r)["id"]
 
-	var airport model.AirportV2
+	if err := area.Validate(); err != nil {
+		return badRequest(err)
+	}
 
-	err := json.NewDecoder(r.Body).Decode(&airport)
+	if err := env.geoRepository.UpdateCustomArea(&area); err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeCustomAreaNotFound, "custom area not found"))
+		}
 
-	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		return errorJSON(r, err)
 	}
 
-	err = env.geoRepository.UpdateAirport(&airport)
+	return api.DataJSON(http.StatusOK, area, nil)
+}
 
-	if err != nil {
-		txn.NoticeError(err)
+func deleteCustomArea(r *http.Request) *api.Response {
+	if err := env.geoRepository.DeleteCustomArea(mux.Vars(r)["id"]); err != nil {
+		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeCustomAreaNotFound, "custom area not found"))
+		}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, airport, nil)
+	return api.DataJSON(http.StatusNoContent, nil, nil)
 }
 
 func saveGeoRegion(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	var region model.GeoRegion
 	err := json.NewDecoder(r.Body).Decode(&region)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	center, errResp := polygonCenter(r, &region)
+
+	if errResp != nil {
+		return errResp
 	}
 
 	err = env.geoRepository.SaveGeoRegion(&region)
 
 	if err != nil {
-		txn.NoticeError(err)
+		return errorJSON(r, err)
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if center != nil {
+		if err = env.geoRepository.UpdateRegionCenter(region.Type, region.GeoID, *center); err != nil {
+			return errorJSON(r, err)
+		}
 	}
 
 	return api.DataJSON(http.StatusOK, region, nil)
 }
 
 func updateGeoRegion(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	var region model.GeoRegion
 
 	err := json.NewDecoder(r.Body).Decode(&region)
 
 	if err != nil {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("failed to read body"), nil)
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidBody, "failed to read body"))
+	}
+
+	center, errResp := polygonCenter(r, &region)
+
+	if errResp != nil {
+		return errResp
 	}
 
 	err = env.geoRepository.UpdateGeoRegion(&region)
 
 	if err != nil {
-		txn.NoticeError(err)
+		return errorJSON(r, err)
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if center != nil {
+		if err = env.geoRepository.UpdateRegionCenter(region.Type, region.GeoID, *center); err != nil {
+			return errorJSON(r, err)
+		}
 	}
 
 	return api.DataJSON(http.StatusOK, region, nil)
@@ -577,8 +1528,6 @@ func updateGeoRegion(r *http.Request) *api.Response {
 // V1
 
 func getCitiesHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	qp := r.URL.Query()
 	countryCode := qp.Get("country_code")
 	countryID := qp.Get("country_id")
@@ -595,9 +1544,7 @@ func getCitiesHandler(r *http.Request) *api.Response {
 				return api.DataJSON(http.StatusOK, []model.City{}, nil)
 			}
 
-			txn.NoticeError(err)
-
-			return api.ErrJSON(http.StatusInternalServerError, err, nil)
+			return errorJSON(r, err)
 		}
 
 		if len(countries) == 0 {
@@ -620,17 +1567,17 @@ func getCitiesHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.City{}, nil)
 		}
 
-		txn.NoticeError(err)
+		return errorJSON(r, err)
+	}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if iataCode != "" {
+		env.shadowReader.CompareCities(cities)
 	}
 
-	return api.DataJSON(http.StatusOK, cities, nil)
+	return api.DataJSON(http.StatusOK, localized(r, cities), nil)
 }
 
 func getCountriesHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	qp := r.URL.Query()
 	q := repository.CountryQuery{
 		Alpha2Code: qp.Get("alpha2_code"),
@@ -644,54 +1591,45 @@ func getCountriesHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.Country{}, nil)
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, countries, nil)
+	return api.DataJSON(http.StatusOK, localized(r, countries), nil)
 }
 
 func getCountryByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	country, err := env.geoRepositoryV1.FindCountryByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.DataJSON(http.StatusNotFound, fmt.Errorf("country not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "country not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, country, nil)
+	return api.DataJSON(http.StatusOK, localized(r, country), nil)
 }
 
 func getStateByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	state, err := env.geoRepositoryV1.FindStateByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.DataJSON(http.StatusNotFound, fmt.Errorf("state not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "state not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, state, nil)
+	return api.DataJSON(http.StatusOK, localized(r, state), nil)
 }
 
 func getStatesHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	qp := r.URL.Query()
 	countryID := qp.Get("country_id")
 
@@ -706,9 +1644,7 @@ func getStatesHandler(r *http.Request) *api.Response {
 				return api.DataJSON(http.StatusOK, []model.State{}, nil)
 			}
 
-			txn.NoticeError(err)
-
-			return api.ErrJSON(http.StatusInternalServerError, err, nil)
+			return errorJSON(r, err)
 		}
 
 		if len(countries) == 0 {
@@ -727,33 +1663,29 @@ func getStatesHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.State{}, nil)
 		}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, states, nil)
+	return api.DataJSON(http.StatusOK, localized(r, states), nil)
 }
 
 func getNeighbourhoodsByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	neighbourhood, err := env.geoRepositoryV1.FindNeighbourhoodByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("neighborhood not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "neighborhood not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, neighbourhood, nil)
+	return api.DataJSON(http.StatusOK, localized(r, neighbourhood), nil)
 }
 
 func getNeighbourhoodsHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	qp := r.URL.Query()
 	q := repository.NeighbourhoodQuery{
 		CountryID: qp.Get("country_id"),
@@ -768,35 +1700,29 @@ func getNeighbourhoodsHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.Neighbourhood{}, nil)
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, neighbourhoods, nil)
+	return api.DataJSON(http.StatusOK, localized(r, neighbourhoods), nil)
 }
 
 func getAirportByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	airport, err := env.geoRepositoryV1.FindAirportByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.DataJSON(http.StatusOK, fmt.Errorf("airport not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAirportNotFound, "airport not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, airport, nil)
+	return api.DataJSON(http.StatusOK, localized(r, airport), nil)
 }
 
 func getAirportsHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	qp := r.URL.Query()
 	q := repository.AirportQuery{
 		CountryID: qp.Get("country_id"),
@@ -812,21 +1738,17 @@ func getAirportsHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.Airport{}, nil)
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, airports, nil)
+	return api.DataJSON(http.StatusOK, localized(r, airports), nil)
 }
 
 func getAccommodationsHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	geoID := r.URL.Query().Get("geo_id")
 
 	if geoID == "" {
-		return api.ErrJSON(http.StatusBadRequest, fmt.Errorf("[geo_id] must be valid"), nil)
+		return errorJSON(r, pkgErrors.Invalid("geo_id", "must be valid"))
 	}
 
 	entity, err := env.geoRepositoryV1.FindGeoEntityByID(geoID)
@@ -836,7 +1758,7 @@ func getAccommodationsHandler(r *http.Request) *api.Response {
 			return api.DataJSON(http.StatusOK, []model.Accommodation{}, nil)
 		}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	// Intersect geo entities
@@ -847,164 +1769,148 @@ func getAccommodationsHandler(r *http.Request) *api.Response {
 		model.GeoEntityTypeAirport})
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	result := env.geoService.MapGeoEntitiesToAccommodation(entities)
 
-	return api.DataJSON(http.StatusOK, result, nil)
+	return api.DataJSON(http.StatusOK, localized(r, result), nil)
 }
 
 func getAccommodationByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	accommodation, err := env.geoRepositoryV1.FindAccommodationByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("accommodation not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeAccommodationNotFound, "accommodation not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, accommodation, nil)
+	return api.DataJSON(http.StatusOK, localized(r, accommodation), nil)
 }
 
 func insertAccommodationGeometry(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
-
 	var accommodation model.AccommodationGeometry
 
 	err := json.NewDecoder(r.Body).Decode(&accommodation)
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return badRequest(err)
 	}
 
 	id, err := env.geoRepositoryV1.InsertAccommodationV1(accommodation.Accommodation)
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	err = env.geoRepositoryV1.InsertGeoEntity(accommodation.Location, *id)
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	return api.DataJSON(http.StatusCreated, map[string]string{"id": *id}, nil)
 }
 
 func intersectsGeoEntities(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	qp := r.URL.Query()
 	latitude, err := strconv.ParseFloat(qp.Get("latitude"), 64)
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number. %w", err).WithField("latitude"))
 	}
 
 	longitude, err := strconv.ParseFloat(qp.Get("longitude"), 64)
 
 	if err != nil {
-		log.Error(err)
-
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return errorJSON(r, pkgErrors.New(pkgErrors.CodeInvalidCoordinates, "must be a number. %w", err).WithField("longitude"))
 	}
 
-	entities, err := env.geoRepositoryV1.IntersectsGeoEntities(*model.NewPointGeometry([]interface{}{longitude, latitude}), []model.GeoEntityType{})
+	point := *model.NewPointGeometry([]interface{}{longitude, latitude})
 
-	if err != nil {
-		txn.NoticeError(err)
+	entities, err := env.geoRepositoryV1.IntersectsGeoEntities(point, []model.GeoEntityType{})
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+	if err != nil {
+		return errorJSON(r, err)
 	}
 
+	env.shadowReader.CompareIntersections(point, entities)
+
 	result := env.geoService.MapGeoEntitiesToBaseEntities(entities)
 
-	return api.DataJSON(http.StatusOK, result, nil)
+	return api.DataJSON(http.StatusOK, localized(r, result), nil)
 }
 
 func updateCityByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	var city model.City
 	err := json.NewDecoder(r.Body).Decode(&city)
 
 	if err != nil {
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusBadRequest, err, nil)
+		return badRequest(err)
 	}
 
 	err = env.geoRepositoryV1.UpdateCityByID(id, city)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("city not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "city not found"))
 		}
 
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	return api.DataJSON(http.StatusOK, struct{}{}, nil)
 }
 
 func getCityByIDHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	id := mux.Vars(r)["id"]
 
 	city, err := env.geoRepositoryV1.FindCityByID(id)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrEntityNotFound) {
-			return api.ErrJSON(http.StatusNotFound, fmt.Errorf("city not found"), nil)
+			return errorJSON(r, pkgErrors.New(pkgErrors.CodeRegionNotFound, "city not found"))
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
-	return api.DataJSON(http.StatusOK, city, nil)
+	return api.DataJSON(http.StatusOK, localized(r, city), nil)
 }
 
 func getEntitiesByIataCodeHandler(r *http.Request) *api.Response {
-	txn := newrelic.FromContext(r.Context())
 	qp := r.URL.Query()
 	iataCode := qp.Get("iata_code")
-	language := qp.Get("language")
 
-	entity, err := env.geoService.GetEntitiesByIataCode(iataCode, language)
+	requested := requestedLanguages(r)
+
+	if language := qp.Get("language"); language != "" {
+		requested = []model.Language{model.Language(language)}
+	}
+
+	var languages []model.Language
+
+	if len(requested) > 0 {
+		languages = model.LanguageChain(requested, fallbackLanguages())
+	}
+
+	entity, err := env.geoService.GetEntitiesByIataCode(iataCode, languages)
 
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrMissingParameters) {
-			return api.ErrJSON(http.StatusBadRequest, err, nil)
+			return badRequest(err)
 		} else if errors.Is(err, pkgErrors.ErrEntityNotFound) {
 			return api.DataJSON(http.StatusOK, []model.Entity{}, nil)
 		}
 
-		txn.NoticeError(err)
-
-		return api.ErrJSON(http.StatusInternalServerError, err, nil)
+		return errorJSON(r, err)
 	}
 
 	return api.DataJSON(http.StatusOK, entity, nil)
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/model"
+)
+
+// requestedLanguages returns the languages asked by the client, the lang query param has precedence over Accept-Language
+func requestedLanguages(r *http.Request) []model.Language {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		var langs []model.Language
+
+		for _, l := range strings.Split(lang, ",") {
+			langs = append(langs, model.Language(strings.TrimSpace(l)))
+		}
+
+		return langs
+	}
+
+	return model.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+func fallbackLanguages() []model.Language {
+	fallback := make([]model.Language, 0, len(conf.GetProps().Languages.Fallback))
+
+	for _, l := range conf.GetProps().Languages.Fallback {
+		fallback = append(fallback, model.Language(l))
+	}
+
+	return fallback
+}
+
+// localized projects the names of the data to a single language when the client asks for one
+func localized(r *http.Request, data interface{}) interface{} {
+	requested := requestedLanguages(r)
+
+	if len(requested) == 0 {
+		return data
+	}
+
+	return model.Localize(data, model.LanguageChain(requested, fallbackLanguages()))
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/basset-la/api-geo/conf"
+	pkgErrors "github.com/basset-la/api-geo/errors"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// pagination holds the paging options of a list request
+type pagination struct {
+	Limit int
+	Page  int
+	After string
+	Total bool
+}
+
+func pageLimits() (int, int) {
+	def, max := conf.GetProps().Pagination.DefaultLimit, conf.GetProps().Pagination.MaxLimit
+
+	if max <= 0 {
+		max = maxPageLimit
+	}
+
+	if def <= 0 {
+		def = defaultPageLimit
+	}
+
+	if def > max {
+		def = max
+	}
+
+	return def, max
+}
+
+// parsePagination reads limit, cursor, page and total. The page param is kept for old clients and cannot be combined with a cursor.
+func parsePagination(qp url.Values) (*pagination, error) {
+	def, max := pageLimits()
+
+	p := pagination{Limit: def}
+
+	var err error
+
+	if qslimit := qp.Get("limit"); len(qslimit) > 0 {
+		p.Limit, err = strconv.Atoi(qslimit)
+
+		if err != nil || p.Limit <= 0 || p.Limit > max {
+			return nil, pkgErrors.Invalid("limit", "must be a number between 1 and %d", max).WithDetail("max", max)
+		}
+	}
+
+	if qspage := qp.Get("page"); len(qspage) > 0 {
+		p.Page, err = strconv.Atoi(qspage)
+
+		if err != nil {
+			return nil, pkgErrors.Invalid("page", "must be a valid number")
+		}
+
+		if p.Page <= 0 {
+			return nil, pkgErrors.Invalid("page", "must be a number greater than 0")
+		}
+	}
+
+	if cursor := qp.Get("cursor"); len(cursor) > 0 {
+		if p.Page > 0 {
+			return nil, pkgErrors.Invalid("cursor", "and [page] cannot be used together")
+		}
+
+		after, err := base64.RawURLEncoding.DecodeString(cursor)
+
+		if err != nil || len(after) == 0 {
+			return nil, pkgErrors.Invalid("cursor", "is not valid")
+		}
+
+		p.After = string(after)
+	}
+
+	if qstotal := qp.Get("total"); len(qstotal) > 0 {
+		p.Total, err = strconv.ParseBool(qstotal)
+
+		if err != nil {
+			return nil, pkgErrors.Invalid("total", "must be true or false")
+		}
+	}
+
+	return &p, nil
+}
+
+// headers returns the Link header to the next page, when the page is full, and the total count when it was asked
+func (p *pagination) headers(r *http.Request, size int, lastKey string, total int) map[string]string {
+	headers := map[string]string{}
+
+	if size >= p.Limit && lastKey != "" {
+		qp := r.URL.Query()
+		qp.Del("page")
+		qp.Set("cursor", base64.RawURLEncoding.EncodeToString([]byte(lastKey)))
+		qp.Set("limit", strconv.Itoa(p.Limit))
+
+		next := url.URL{Path: r.URL.Path, RawQuery: qp.Encode()}
+
+		headers["Link"] = fmt.Sprintf("<%s>; rel=\"next\"", next.String())
+	}
+
+	if p.Total {
+		headers["X-Total-Count"] = strconv.Itoa(total)
+	}
+
+	return headers
+}
@@ -20,6 +20,13 @@ var routes = http.Routes{
 		HandlerFunc: docs.DocsHandler,
 		ShouldLog:   false,
 	},
+	{
+		Name:        "Shadow Read Stats",
+		Method:      "GET",
+		Pattern:     "/shadow-read",
+		HandlerFunc: shadowReadStatsHandler,
+		ShouldLog:   false,
+	},
 
 	// V2
 	{
@@ -110,6 +117,30 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Expand airport code V2",
+		Method:      "GET",
+		Pattern:     "/v2/airports/expand/{code}",
+		HandlerFunc: expandAirportCode,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Find metro areas by code V2",
+		Method:      "GET",
+		Pattern:     "/v2/metro-areas/{code}",
+		HandlerFunc: getMetroAreaByCode,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Find custom areas by ID V2",
+		Method:      "GET",
+		Pattern:     "/v2/custom-areas/{id}",
+		HandlerFunc: getCustomArea,
+		ShouldLog:   true,
+	},
+
 	{
 		Name:        "Find polygons by ID V2",
 		Method:      "GET",
@@ -118,6 +149,30 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Polygon metrics V2",
+		Method:      "GET",
+		Pattern:     "/v2/polygons/{id}/metrics",
+		HandlerFunc: getPolygonMetrics,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Geometry operations V2",
+		Method:      "POST",
+		Pattern:     "/v2/geometry/ops",
+		HandlerFunc: geometryOps,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Dataset diff V2",
+		Method:      "POST",
+		Pattern:     "/v2/diff",
+		HandlerFunc: diffDataset,
+		ShouldLog:   false,
+	},
+
 	{
 		Name:        "Save region V2",
 		Method:      "POST",
@@ -134,6 +189,46 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Find region accommodations V2",
+		Method:      "GET",
+		Pattern:     "/v2/regions/{type}/{id}/accommodations",
+		HandlerFunc: getRegionAccommodations,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Find region aliases V2",
+		Method:      "GET",
+		Pattern:     "/v2/regions/{type}/{id}/aliases",
+		HandlerFunc: getRegionAliases,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Add region aliases V2",
+		Method:      "POST",
+		Pattern:     "/v2/regions/{type}/{id}/aliases",
+		HandlerFunc: addRegionAliases,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Replace region aliases V2",
+		Method:      "PUT",
+		Pattern:     "/v2/regions/{type}/{id}/aliases",
+		HandlerFunc: replaceRegionAliases,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Delete region alias V2",
+		Method:      "DELETE",
+		Pattern:     "/v2/regions/{type}/{id}/aliases/{lang}/{alias}",
+		HandlerFunc: deleteRegionAlias,
+		ShouldLog:   true,
+	},
+
 	{
 		Name:        "Save airport V2",
 		Method:      "POST",
@@ -150,6 +245,46 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Save metro area V2",
+		Method:      "POST",
+		Pattern:     "/v2/metro-areas",
+		HandlerFunc: saveMetroArea,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Update metro area V2",
+		Method:      "PUT",
+		Pattern:     "/v2/metro-areas",
+		HandlerFunc: updateMetroArea,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Save custom area V2",
+		Method:      "POST",
+		Pattern:     "/v2/custom-areas",
+		HandlerFunc: saveCustomArea,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Update custom area V2",
+		Method:      "PUT",
+		Pattern:     "/v2/custom-areas/{id}",
+		HandlerFunc: updateCustomArea,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Delete custom area V2",
+		Method:      "DELETE",
+		Pattern:     "/v2/custom-areas/{id}",
+		HandlerFunc: deleteCustomArea,
+		ShouldLog:   true,
+	},
+
 	{
 		Name:        "Save geo region V2",
 		Method:      "POST",
@@ -256,6 +391,22 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Find custom areas V2",
+		Method:      "GET",
+		Pattern:     "/v2/custom-areas",
+		HandlerFunc: getCustomAreasByQuery,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Find metro areas V2",
+		Method:      "GET",
+		Pattern:     "/v2/metro-areas",
+		HandlerFunc: getMetroAreasByQuery,
+		ShouldLog:   true,
+	},
+
 	{
 		Name:        "Find intersections V2",
 		Method:      "GET",
@@ -264,6 +415,14 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Find timezone V2",
+		Method:      "GET",
+		Pattern:     "/v2/timezone",
+		HandlerFunc: getTimezone,
+		ShouldLog:   true,
+	},
+
 	{
 		Name:        "Get Regions Nearby",
 		Method:      "GET",
@@ -280,6 +439,22 @@ var routes = http.Routes{
 		ShouldLog:   true,
 	},
 
+	{
+		Name:        "Find by geohash V2",
+		Method:      "GET",
+		Pattern:     "/v2/geohash/{prefix}",
+		HandlerFunc: getGeohash,
+		ShouldLog:   true,
+	},
+
+	{
+		Name:        "Accommodation clusters V2",
+		Method:      "GET",
+		Pattern:     "/v2/accommodations/clusters",
+		HandlerFunc: getAccommodationClusters,
+		ShouldLog:   true,
+	},
+
 	// V1
 
 	{
@@ -1,16 +1,25 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/basset-la/api-geo/conf"
+	"github.com/basset-la/api-geo/graph"
+	"github.com/basset-la/api-geo/grpcserver"
 	"github.com/basset-la/api-geo/repository"
 	"github.com/basset-la/api-geo/service"
 	utils "github.com/basset-la/utils/v4/http"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/sirupsen/logrus"
 	"github.com/swaggo/swag/example/basic/docs"
+	"google.golang.org/grpc"
 )
 
 func Start() {
@@ -33,7 +42,7 @@ func Start() {
 		panic(err)
 	}
 
-	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.GeoCoordinatesTable)
+	repo, err := repository.NewMongoRepository(conf.GetProps().Mongo.URI, conf.GetProps().Mongo.DB, conf.GetProps().Mongo.AirportsTable, conf.GetProps().Mongo.MetroAreasTable, conf.GetProps().Mongo.GeoCoordinatesTable, conf.GetProps().Mongo.TimezonesTable)
 
 	if err != nil {
 		panic(fmt.Errorf("failed to create mongo repository. %w", err))
@@ -50,15 +59,85 @@ func Start() {
 	defer repoV1.Close()
 
 	geoService := service.NewGeoService(repoV1)
+	shadowProps := conf.GetProps().ShadowRead
+	shadowReader := service.NewShadowReader(repo, repoV1, shadowProps.Enabled, shadowProps.SampleRate, shadowProps.MaxConcurrent)
+
+	defaultLimit, maxLimit := pageLimits()
+
+	graphExecutor, err := graph.NewExecutor(repo, defaultLimit, maxLimit, fallbackLanguages())
+
+	if err != nil {
+		panic(err)
+	}
 
 	env = AppEnv{
 		geoRepository:   repo,
 		geoRepositoryV1: repoV1,
 		geoService:      geoService,
+		shadowReader:    shadowReader,
+		graph:           graphExecutor,
+	}
+
+	var grpcServer *grpc.Server
+
+	if port := conf.GetProps().Grpc.Port; port > 0 {
+		grpcServer = grpcserver.NewGRPCServer(grpcserver.NewServer(repo, defaultLimit, maxLimit), nrApp)
+
+		go func() {
+			if err := grpcserver.Serve(grpcServer, port); err != nil {
+				logrus.Fatal(err)
+			}
+		}()
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(newrelic.WrapHandleFunc(nrApp, conf.GetProps().App.Path+exportPath, exportHandler))
+	router.HandleFunc(newrelic.WrapHandleFunc(nrApp, conf.GetProps().App.Path+graphqlPath, graphqlHandler))
+	router.Handle("/", utils.NewRouterWithNewRelic(conf.GetProps().App.Path, routes, nrApp))
+
+	httpServer := &http.Server{Addr: ":8080", Handler: router}
+
+	go func() {
+		logrus.Info("Application listen in port 8080")
+
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.Fatal(err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	shutdown(httpServer, grpcServer)
+}
+
+// shutdownTimeout is how long the running requests have to finish once the app is asked to stop
+const shutdownTimeout = 30 * time.Second
+
+// shutdown drains both servers at the same time, the requests still running after the timeout are cancelled
+func shutdown(httpServer *http.Server, grpcServer *grpc.Server) {
+	logrus.Info("Shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	if grpcServer != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			grpcserver.Shutdown(ctx, grpcServer)
+		}()
+	}
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logrus.Errorf("Failed to shut down the http server. %v", err)
 	}
 
-	logrus.Info("Application listen in port 8080")
-	logrus.Fatal(http.ListenAndServe(":8080", utils.NewRouterWithNewRelic(conf.GetProps().App.Path, routes, nrApp)))
+	wg.Wait()
 }
 
 var env AppEnv
@@ -67,4 +146,6 @@ type AppEnv struct {
 	geoRepository   *repository.MongoRepository
 	geoRepositoryV1 *repository.MongoRepositoryV1
 	geoService      *service.GeoService
+	shadowReader    *service.ShadowReader
+	graph           *graph.Executor
 }
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// validateRegion checks the fields of a region and then that its ancestors exist, with one query per ancestor type
+func validateRegion(region *model.Region) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+
+	idsByType := map[model.RegionType][]string{}
+
+	for _, a := range region.Ancestors {
+		idsByType[a.Type] = append(idsByType[a.Type], a.ID)
+	}
+
+	existing := map[model.RegionType]map[string]bool{}
+
+	for regionType, ids := range idsByType {
+		regions := make([]model.Region, 0, len(ids))
+		q := repository.QueryRegion{RegionType: regionType, GeoIDs: ids, Limit: len(ids), Fields: bson.M{"geo_id": 1}}
+
+		if err := env.geoRepository.GetRegions(q, &regions); err != nil {
+			return fmt.Errorf("failed to get the %s ancestors. %w", regionType, err)
+		}
+
+		existing[regionType] = map[string]bool{}
+
+		for _, r := range regions {
+			existing[regionType][r.GeoID] = true
+		}
+	}
+
+	var fields []*pkgErrors.Error
+
+	for i, a := range region.Ancestors {
+		if !existing[a.Type][a.ID] {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeInvalidAncestor, "%s %s does not exist", a.Type, a.ID).WithField(fmt.Sprintf("ancestors[%d]", i)))
+		}
+	}
+
+	return pkgErrors.Validation(fields)
+}
+
+// validateMetroArea normalizes and checks the metro area, its city and airports must exist
+func validateMetroArea(metro *model.MetroArea) error {
+	metro.Normalize()
+
+	if err := metro.Validate(); err != nil {
+		return err
+	}
+
+	var fields []*pkgErrors.Error
+
+	var city model.Region
+
+	if err := env.geoRepository.GetRegionByTypeAndGeoID(model.RegionTypeCity, metro.City.ID, &city); err != nil {
+		if !errors.Is(err, pkgErrors.ErrEntityNotFound) {
+			return fmt.Errorf("failed to get the city of the metro area. %w", err)
+		}
+
+		fields = append(fields, pkgErrors.New(pkgErrors.CodeRegionNotFound, "city %s does not exist", metro.City.ID).WithField("city.id"))
+	}
+
+	airports := make([]model.AirportV2, 0, len(metro.Airports))
+	q := repository.QueryAirport{IataCodes: metro.Airports, Fields: bson.M{"iata": 1}}
+
+	if err := env.geoRepository.GetAirportByQuery(q, &airports); err != nil {
+		return fmt.Errorf("failed to get the airports of the metro area. %w", err)
+	}
+
+	existing := make(map[string]bool, len(airports))
+
+	for _, a := range airports {
+		existing[a.IataCode] = true
+	}
+
+	for i, code := range metro.Airports {
+		if !existing[code] {
+			fields = append(fields, pkgErrors.New(pkgErrors.CodeAirportNotFound, "airport %s does not exist", code).WithField(fmt.Sprintf("airports[%d]", i)))
+		}
+	}
+
+	return pkgErrors.Validation(fields)
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"fmt"
-	"strings"
 	"sync"
 
 	pkgErrors "github.com/basset-la/api-geo/errors"
@@ -17,7 +16,7 @@ type Service interface {
 	MapGeoEntityToBaseEntity(geoEntity model.GeoEntity) (*model.BaseEntity, error)
 	MapGeoEntitiesToAccommodation(geoEntities []model.GeoEntity) map[string]*model.Accommodation
 	MapGeoEntityToAccommodation(geoEntity model.GeoEntity) (*model.Accommodation, error)
-	GetEntitiesByIataCode(iataCode string, language string) ([]model.Entity, error)
+	GetEntitiesByIataCode(iataCode string, languages []model.Language) ([]model.Entity, error)
 }
 
 type GeoService struct {
@@ -100,8 +99,9 @@ func (s *GeoService) MapGeoEntityToAccommodation(geoEntity model.GeoEntity) (*mo
 	return nil, fmt.Errorf("geo entity %s must be accommodation type", geoEntity.Type)
 }
 
-func (s *GeoService) GetEntitiesByIataCode(iataCode string, language string) ([]model.Entity, error) {
-	err := s.validateRequest(iataCode, language)
+// GetEntitiesByIataCode returns the cities and airports with the iata code, named in the first translated language
+func (s *GeoService) GetEntitiesByIataCode(iataCode string, languages []model.Language) ([]model.Entity, error) {
+	err := s.validateRequest(iataCode, languages)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +115,7 @@ func (s *GeoService) GetEntitiesByIataCode(iataCode string, language string) ([]
 	wg.Add(1)
 
 	go func() {
-		cities, err := s.findCities(iataCode, language)
+		cities, err := s.findCities(iataCode, languages)
 
 		if err != nil {
 			errs = append(errs, err.Error())
@@ -129,7 +129,7 @@ func (s *GeoService) GetEntitiesByIataCode(iataCode string, language string) ([]
 	wg.Add(1)
 
 	go func() {
-		airports, err := s.findAirports(iataCode, language)
+		airports, err := s.findAirports(iataCode, languages)
 
 		if err != nil {
 			errs = append(errs, err.Error())
@@ -149,15 +149,15 @@ func (s *GeoService) GetEntitiesByIataCode(iataCode string, language string) ([]
 	return result, nil
 }
 
-func (s *GeoService) validateRequest(iataCode string, language string) error {
-	if iataCode == "" || language == "" {
+func (s *GeoService) validateRequest(iataCode string, languages []model.Language) error {
+	if iataCode == "" || len(languages) == 0 {
 		return pkgErrors.ErrMissingParameters
 	}
 
 	return nil
 }
 
-func (s *GeoService) findAirports(iataCode string, language string) ([]model.Entity, error) {
+func (s *GeoService) findAirports(iataCode string, languages []model.Language) ([]model.Entity, error) {
 	aq := repository.AirportQuery{
 		IataCode: iataCode,
 	}
@@ -174,7 +174,7 @@ func (s *GeoService) findAirports(iataCode string, language string) ([]model.Ent
 		result = append(result, model.Entity{
 			ID:        airport.ID.Hex(),
 			IataCode:  airport.IataCode,
-			Name:      airport.Name[model.Language(strings.ToLower(language))],
+			Name:      model.LocalizedName(airport.Name, languages),
 			Type:      model.GeoEntityTypeAirport,
 			CountryID: airport.CountryID.Hex(),
 		})
@@ -183,7 +183,7 @@ func (s *GeoService) findAirports(iataCode string, language string) ([]model.Ent
 	return result, nil
 }
 
-func (s *GeoService) findCities(iataCode string, language string) ([]model.Entity, error) {
+func (s *GeoService) findCities(iataCode string, languages []model.Language) ([]model.Entity, error) {
 	cq := repository.CityQuery{
 		IataCode: iataCode,
 	}
@@ -199,7 +199,7 @@ func (s *GeoService) findCities(iataCode string, language string) ([]model.Entit
 		result = append(result, model.Entity{
 			ID:        city.ID.Hex(),
 			IataCode:  city.IataCode,
-			Name:      city.Name[model.Language(strings.ToLower(language))],
+			Name:      model.LocalizedName(city.Name, languages),
 			Type:      model.GeoEntityTypeCity,
 			CountryID: city.CountryID.Hex(),
 		})
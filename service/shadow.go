@@ -0,0 +1,305 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Shadow read comparisons
+const (
+	ShadowIntersections = "intersections"
+	ShadowCities        = "cities"
+)
+
+// ShadowStats counts the results of the comparisons made for a V1 endpoint
+type ShadowStats struct {
+	Comparisons int64 `json:"comparisons"`
+	Mismatches  int64 `json:"mismatches"`
+	Errors      int64 `json:"errors"`
+	// Dropped are the comparisons skipped because too many were running
+	Dropped int64 `json:"dropped"`
+}
+
+// defaultShadowConcurrency is the amount of comparisons running at the same time when it is not configured
+const defaultShadowConcurrency = 4
+
+// shadowRepository is the part of the V2 repository the comparisons read
+type shadowRepository interface {
+	GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error
+	GetRegions(query repository.QueryRegion, r *[]model.Region) error
+}
+
+// shadowRepositoryV1 finds the geo ids of the V1 entities
+type shadowRepositoryV1 interface {
+	FindGeoIDs(entityType model.GeoEntityType, ids []bson.ObjectId) (map[bson.ObjectId]string, error)
+}
+
+// ShadowReader computes in background the V2 answer of V1 requests and counts the differences
+type ShadowReader struct {
+	repo       shadowRepository
+	repoV1     shadowRepositoryV1
+	enabled    bool
+	sampleRate float64
+	random     func() float64
+	slots      chan struct{}
+	mu         sync.Mutex
+	stats      map[string]*ShadowStats
+}
+
+// NewShadowReader compares a sample of the requests, sampleRate between 0 and 1 (every request when out of range),
+// with at most maxConcurrent comparisons running; the ones that do not fit are dropped.
+func NewShadowReader(r *repository.MongoRepository, rV1 *repository.MongoRepositoryV1, enabled bool, sampleRate float64, maxConcurrent int) *ShadowReader {
+	return newShadowReader(r, rV1, enabled, sampleRate, maxConcurrent)
+}
+
+func newShadowReader(r shadowRepository, rV1 shadowRepositoryV1, enabled bool, sampleRate float64, maxConcurrent int) *ShadowReader {
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultShadowConcurrency
+	}
+
+	return &ShadowReader{
+		repo:       r,
+		repoV1:     rV1,
+		enabled:    enabled,
+		sampleRate: sampleRate,
+		random:     rand.Float64,
+		slots:      make(chan struct{}, maxConcurrent),
+		stats:      map[string]*ShadowStats{},
+	}
+}
+
+// Enabled returns if the shadow read mode is active
+func (s *ShadowReader) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// Stats returns a copy of the counters of every comparison
+func (s *ShadowReader) Stats() map[string]ShadowStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]ShadowStats, len(s.stats))
+
+	for k, v := range s.stats {
+		result[k] = *v
+	}
+
+	return result
+}
+
+// CompareIntersections checks that every V1 entity intersected by the geometry is also returned by V2
+func (s *ShadowReader) CompareIntersections(geometry model.Geometry, entities []model.GeoEntity) {
+	s.run(ShadowIntersections, func() ([]string, error) { return s.intersectionDiffs(geometry, entities) })
+}
+
+// CompareCities checks that every V1 city has a V2 region with the same names
+func (s *ShadowReader) CompareCities(cities []model.City) {
+	s.run(ShadowCities, func() ([]string, error) { return s.cityDiffs(cities) })
+}
+
+func (s *ShadowReader) intersectionDiffs(geometry model.Geometry, entities []model.GeoEntity) ([]string, error) {
+	regions := make([]model.GeoRegion, 0)
+
+	err := s.repo.GetIntersectedRegions(geometry, nil, &regions)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, r := range regions {
+		found[string(r.Type)+":"+r.GeoID] = true
+	}
+
+	geoIDs, err := s.geoIDsOf(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+
+	for _, e := range entities {
+		regionType := e.Type.RegionType()
+		if regionType == "" || regionType == model.RegionTypeAccommodation {
+			continue
+		}
+
+		geoID := geoIDs[e.ID]
+		if geoID == "" {
+			diffs = append(diffs, fmt.Sprintf("%s %s has no geo id", e.Type, e.ID.Hex()))
+
+			continue
+		}
+
+		if !found[string(regionType)+":"+geoID] {
+			diffs = append(diffs, fmt.Sprintf("%s %s not intersected", regionType, geoID))
+		}
+	}
+
+	return diffs, nil
+}
+
+func (s *ShadowReader) cityDiffs(cities []model.City) ([]string, error) {
+	var diffs []string
+
+	geoIDs := make([]string, 0, len(cities))
+
+	for _, c := range cities {
+		if c.GeoID == "" {
+			diffs = append(diffs, fmt.Sprintf("city %s has no geo id", c.ID.Hex()))
+
+			continue
+		}
+
+		geoIDs = append(geoIDs, c.GeoID)
+	}
+
+	if len(geoIDs) == 0 {
+		return diffs, nil
+	}
+
+	regions := make([]model.Region, 0)
+
+	err := s.repo.GetRegions(repository.QueryRegion{RegionType: model.RegionTypeCity, GeoIDs: geoIDs}, &regions)
+	if err != nil {
+		return nil, err
+	}
+
+	byGeoID := make(map[string]model.Region, len(regions))
+	for _, r := range regions {
+		byGeoID[r.GeoID] = r
+	}
+
+	for _, c := range cities {
+		if c.GeoID == "" {
+			continue
+		}
+
+		r, ok := byGeoID[c.GeoID]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("city %s not found", c.GeoID))
+
+			continue
+		}
+
+		for lang, name := range c.Name {
+			if r.Name[lang] != name {
+				diffs = append(diffs, fmt.Sprintf("city %s name[%s] %q != %q", c.GeoID, lang, name, r.Name[lang]))
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+// geoIDsOf returns the geo id of the entities with one V1 query per entity type
+func (s *ShadowReader) geoIDsOf(entities []model.GeoEntity) (map[bson.ObjectId]string, error) {
+	idsByType := map[model.GeoEntityType][]bson.ObjectId{}
+
+	for _, e := range entities {
+		regionType := e.Type.RegionType()
+		if regionType == "" || regionType == model.RegionTypeAccommodation {
+			continue
+		}
+
+		idsByType[e.Type] = append(idsByType[e.Type], e.ID)
+	}
+
+	result := map[bson.ObjectId]string{}
+
+	for entityType, ids := range idsByType {
+		geoIDs, err := s.repoV1.FindGeoIDs(entityType, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, geoID := range geoIDs {
+			result[id] = geoID
+		}
+	}
+
+	return result, nil
+}
+
+// run executes the comparison in background, it never affects the V1 response.
+// The requests out of the sample are not compared and the comparisons are dropped when every slot is taken.
+func (s *ShadowReader) run(name string, compare func() ([]string, error)) {
+	if !s.Enabled() || s.random() >= s.sampleRate {
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.drop(name)
+
+		return
+	}
+
+	go func() {
+		defer func() { <-s.slots }()
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("shadow read %s panicked: %v", name, r)
+				s.count(name, false, true)
+			}
+		}()
+
+		diffs, err := compare()
+
+		if err != nil {
+			log.WithField("shadow_read", name).Error(fmt.Errorf("failed to compare V2 answer. %w", err))
+			s.count(name, false, true)
+
+			return
+		}
+
+		if len(diffs) > 0 {
+			log.WithField("shadow_read", name).WithField("diffs", diffs).Warn("V2 answer differs from V1")
+		}
+
+		s.count(name, len(diffs) > 0, false)
+	}()
+}
+
+func (s *ShadowReader) count(name string, mismatch, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.statsOf(name)
+	st.Comparisons++
+
+	if mismatch {
+		st.Mismatches++
+	}
+
+	if failed {
+		st.Errors++
+	}
+}
+
+func (s *ShadowReader) drop(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statsOf(name).Dropped++
+}
+
+func (s *ShadowReader) statsOf(name string) *ShadowStats {
+	st, ok := s.stats[name]
+	if !ok {
+		st = &ShadowStats{}
+		s.stats[name] = st
+	}
+
+	return st
+}
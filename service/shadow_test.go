@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type fakeShadowRepository struct {
+	intersected []model.GeoRegion
+	regions     []model.Region
+	geoIDs      map[bson.ObjectId]string
+	release     chan struct{}
+	fail        bool
+}
+
+func (f *fakeShadowRepository) GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error {
+	if f.fail {
+		return fmt.Errorf("connection refused")
+	}
+
+	*r = f.intersected
+
+	return nil
+}
+
+func (f *fakeShadowRepository) GetRegions(query repository.QueryRegion, r *[]model.Region) error {
+	if f.release != nil {
+		<-f.release
+	}
+
+	if f.fail {
+		return fmt.Errorf("connection refused")
+	}
+
+	*r = f.regions
+
+	return nil
+}
+
+func (f *fakeShadowRepository) FindGeoIDs(entityType model.GeoEntityType, ids []bson.ObjectId) (map[bson.ObjectId]string, error) {
+	result := map[bson.ObjectId]string{}
+
+	for _, id := range ids {
+		if geoID, ok := f.geoIDs[id]; ok {
+			result[id] = geoID
+		}
+	}
+
+	return result, nil
+}
+
+func city(geoID, name string) model.City {
+	return model.City{BaseEntity: model.BaseEntity{ID: bson.NewObjectId(), Name: map[model.Language]string{"es": name}}, GeoID: geoID}
+}
+
+func cityRegion(geoID, name string) model.Region {
+	return model.Region{BaseRegion: model.BaseRegion{GeoID: geoID, Type: model.RegionTypeCity}, Name: map[model.Language]string{"es": name}}
+}
+
+func waitComparisons(t *testing.T, s *ShadowReader, name string, comparisons int64) ShadowStats {
+	require.Eventually(t, func() bool { return s.Stats()[name].Comparisons == comparisons }, time.Second, time.Millisecond)
+
+	return s.Stats()[name]
+}
+
+func TestShadowReaderSampling(t *testing.T) {
+	// Given
+	repo := &fakeShadowRepository{regions: []model.Region{cityRegion("1", "Rosario")}}
+	s := newShadowReader(repo, repo, true, 0.5, 1)
+	s.random = func() float64 { return 0.7 }
+
+	// When
+	s.CompareCities([]model.City{city("1", "Rosario")})
+
+	// Then
+	assert.Empty(t, s.Stats())
+
+	s.random = func() float64 { return 0.2 }
+	s.CompareCities([]model.City{city("1", "Rosario")})
+
+	assert.Equal(t, ShadowStats{Comparisons: 1}, waitComparisons(t, s, ShadowCities, 1))
+}
+
+func TestShadowReaderDisabled(t *testing.T) {
+	repo := &fakeShadowRepository{}
+	s := newShadowReader(repo, repo, false, 1, 1)
+
+	s.CompareCities([]model.City{city("1", "Rosario")})
+
+	assert.False(t, s.Enabled())
+	assert.Empty(t, s.Stats())
+}
+
+func TestShadowReaderDropsWhenSlotsAreTaken(t *testing.T) {
+	// Given
+	repo := &fakeShadowRepository{regions: []model.Region{cityRegion("1", "Rosario")}, release: make(chan struct{})}
+	s := newShadowReader(repo, repo, true, 1, 1)
+
+	// When
+	s.CompareCities([]model.City{city("1", "Rosario")})
+	s.CompareCities([]model.City{city("1", "Rosario")})
+
+	// Then
+	assert.Equal(t, int64(1), s.Stats()[ShadowCities].Dropped)
+
+	close(repo.release)
+
+	assert.Equal(t, ShadowStats{Comparisons: 1, Dropped: 1}, waitComparisons(t, s, ShadowCities, 1))
+
+	s.CompareCities([]model.City{city("1", "Rosario")})
+
+	assert.Equal(t, ShadowStats{Comparisons: 2, Dropped: 1}, waitComparisons(t, s, ShadowCities, 2))
+}
+
+func TestShadowReaderCompareCities(t *testing.T) {
+	// Given
+	repo := &fakeShadowRepository{regions: []model.Region{cityRegion("1", "Rosario"), cityRegion("2", "Cordoba")}}
+	s := newShadowReader(repo, repo, true, 1, 1)
+	unlinked := city("", "Mendoza")
+
+	// When
+	diffs, err := s.cityDiffs([]model.City{city("1", "Rosario"), city("2", "Córdoba"), city("3", "Salta"), unlinked})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("city %s has no geo id", unlinked.ID.Hex()),
+		`city 2 name[es] "Córdoba" != "Cordoba"`,
+		"city 3 not found",
+	}, diffs)
+
+	s.CompareCities([]model.City{city("2", "Córdoba")})
+
+	assert.Equal(t, ShadowStats{Comparisons: 1, Mismatches: 1}, waitComparisons(t, s, ShadowCities, 1))
+}
+
+func TestShadowReaderCompareIntersections(t *testing.T) {
+	// Given
+	country, city, state, accommodation := bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()
+
+	repo := &fakeShadowRepository{
+		intersected: []model.GeoRegion{{BaseRegion: model.BaseRegion{GeoID: "178", Type: model.RegionTypeCountry}}},
+		geoIDs:      map[bson.ObjectId]string{country: "178", city: "2"},
+	}
+	s := newShadowReader(repo, repo, true, 1, 1)
+
+	entities := []model.GeoEntity{
+		{ID: country, Type: model.GeoEntityTypeCountry},
+		{ID: city, Type: model.GeoEntityTypeCity},
+		{ID: state, Type: model.GeoEntityTypeState},
+		{ID: accommodation, Type: model.GeoEntityTypeAccommodation},
+	}
+
+	// When
+	diffs, err := s.intersectionDiffs(model.Geometry{}, entities)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"city 2 not intersected",
+		fmt.Sprintf("%s %s has no geo id", model.GeoEntityTypeState, state.Hex()),
+	}, diffs)
+
+	repo.fail = true
+	s.CompareIntersections(model.Geometry{}, entities)
+
+	assert.Equal(t, ShadowStats{Comparisons: 1, Errors: 1}, waitComparisons(t, s, ShadowIntersections, 1))
+}
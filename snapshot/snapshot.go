@@ -0,0 +1,130 @@
+// Package snapshot packs the regions, their polygons and the airports into a compact file, and loads it
+// into a read-only Store with the lookups of the MongoRepository, so batch jobs and edge services
+// can resolve geo data without a Mongo connection or an HTTP hop.
+//
+// A snapshot is a gzip compressed gob stream: a header followed by one record per region or airport.
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/basset-la/api-geo/model"
+)
+
+// formatName and formatVersion identify the files written by this package
+const (
+	formatName    = "api-geo-snapshot"
+	formatVersion = 1
+)
+
+type header struct {
+	Format    string
+	Version   int
+	CreatedAt time.Time
+}
+
+// region is a region with its geometry decoded, gob cannot encode the interface{} coordinates
+type region struct {
+	Region       model.Region
+	GeometryType model.GeometryType
+	Polygons     [][][][]float64
+	Point        []float64
+}
+
+// record holds a region or an airport
+type record struct {
+	Region  *region
+	Airport *model.AirportV2
+}
+
+// Writer writes a snapshot, Close must be called to complete the file
+type Writer struct {
+	gz      *gzip.Writer
+	encoder *gob.Encoder
+}
+
+// NewWriter writes the header of a snapshot
+func NewWriter(w io.Writer) (*Writer, error) {
+	gz, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := gob.NewEncoder(gz)
+
+	if err = encoder.Encode(header{Format: formatName, Version: formatVersion, CreatedAt: time.Now().UTC()}); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot header. %w", err)
+	}
+
+	return &Writer{gz: gz, encoder: encoder}, nil
+}
+
+// WriteRegion adds a region with the polygon of its type, when it was exported with polygons.
+// Point geometries are kept, other geometries are dropped.
+func (w *Writer) WriteRegion(r *model.RegionExport) error {
+	rec := &region{Region: r.Region}
+
+	for _, p := range r.Polygons {
+		if p.Type != r.Type || p.Geometry.Type == "" {
+			continue
+		}
+
+		switch p.Geometry.Type {
+		case model.GeometryPolygon, model.GeometryMultiPolygon:
+			polygons, err := p.Geometry.Polygons()
+			if err != nil {
+				return fmt.Errorf("invalid polygon of %s %s. %w", r.Type, r.GeoID, err)
+			}
+
+			rec.GeometryType, rec.Polygons = p.Geometry.Type, polygons
+		case model.GeometryPoint:
+			point, ok := pointOf(p.Geometry)
+			if !ok {
+				return fmt.Errorf("invalid point of %s %s", r.Type, r.GeoID)
+			}
+
+			rec.GeometryType, rec.Point = p.Geometry.Type, point
+		}
+
+		break
+	}
+
+	if err := w.encoder.Encode(record{Region: rec}); err != nil {
+		return fmt.Errorf("failed to write %s %s. %w", r.Type, r.GeoID, err)
+	}
+
+	return nil
+}
+
+// WriteAirport adds an airport
+func (w *Writer) WriteAirport(a *model.AirportV2) error {
+	if err := w.encoder.Encode(record{Airport: a}); err != nil {
+		return fmt.Errorf("failed to write airport %s. %w", a.IataCode, err)
+	}
+
+	return nil
+}
+
+// Close flushes the compressed stream, it does not close the underlying writer
+func (w *Writer) Close() error {
+	return w.gz.Close()
+}
+
+func pointOf(g model.Geometry) ([]float64, bool) {
+	if g.Coordinates == nil {
+		return g.Point, len(g.Point) >= 2
+	}
+
+	if len(g.Coordinates) < 2 {
+		return nil, false
+	}
+
+	lon, okLon := g.Coordinates[0].(float64)
+	lat, okLat := g.Coordinates[1].(float64)
+
+	return []float64{lon, lat}, okLon && okLat
+}
@@ -0,0 +1,188 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func square(minX, minY, maxX, maxY float64) []interface{} {
+	return []interface{}{[]interface{}{
+		[]interface{}{minX, minY},
+		[]interface{}{maxX, minY},
+		[]interface{}{maxX, maxY},
+		[]interface{}{minX, maxY},
+		[]interface{}{minX, minY},
+	}}
+}
+
+func exported(geoID string, t model.RegionType, name string, geometry *model.Geometry, ancestors ...model.Ancestor) *model.RegionExport {
+	r := &model.RegionExport{Region: model.Region{
+		BaseRegion:  model.BaseRegion{GeoID: geoID, Type: t},
+		Name:        map[model.Language]string{"es": name},
+		CountryCode: "AR",
+		Ancestors:   ancestors,
+	}}
+
+	if geometry != nil {
+		r.Polygons = []model.GeoRegion{{BaseRegion: r.BaseRegion, Geometry: *geometry}}
+	}
+
+	return r
+}
+
+func load(t *testing.T) *Store {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf)
+	require.NoError(t, err)
+
+	country := model.Ancestor{ID: "178", Type: model.RegionTypeCountry}
+
+	require.NoError(t, w.WriteRegion(exported("178", model.RegionTypeCountry, "Argentina",
+		&model.Geometry{Type: model.GeometryPolygon, Coordinates: square(-70, -50, -55, -22)})))
+	require.NoError(t, w.WriteRegion(exported("2", model.RegionTypeCity, "Buenos Aires",
+		&model.Geometry{Type: model.GeometryPolygon, Coordinates: square(-58.5, -34.7, -58.3, -34.5)}, country)))
+	require.NoError(t, w.WriteRegion(exported("1", model.RegionTypeCity, "Córdoba",
+		&model.Geometry{Type: model.GeometryMultiPolygon, Coordinates: []interface{}{square(-64.3, -31.5, -64.1, -31.3)}}, country)))
+	require.NoError(t, w.WriteRegion(exported("3", model.RegionTypePOI, "Obelisco",
+		model.NewPointGeometry([]interface{}{-58.3816, -34.6037}), country)))
+	ushuaia := exported("4", model.RegionTypeCity, "Ushuaia", nil, country)
+	ushuaia.Aliases = map[model.Language][]string{"en": {"End of the World"}}
+
+	require.NoError(t, w.WriteRegion(ushuaia))
+	require.NoError(t, w.WriteAirport(&model.AirportV2{IataCode: "EZE", CountryCode: "AR"}))
+	require.NoError(t, w.WriteAirport(&model.AirportV2{IataCode: "AEP", CountryCode: "AR"}))
+	require.NoError(t, w.Close())
+
+	s, err := Load(&buf)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestStoreGetByID(t *testing.T) {
+	s := load(t)
+
+	var r model.Region
+
+	require.NoError(t, s.GetRegionByTypeAndGeoID(model.RegionTypeCity, "2", &r))
+	assert.Equal(t, "Buenos Aires", r.Name["es"])
+
+	err := s.GetRegionByTypeAndGeoID(model.RegionTypeCountry, "2", &r)
+	assert.True(t, errors.Is(err, pkgErrors.ErrEntityNotFound))
+
+	var g model.GeoRegion
+
+	require.NoError(t, s.GetGeoRegion("1", &g))
+	assert.Equal(t, model.GeometryMultiPolygon, g.Geometry.Type)
+	assert.Equal(t, []interface{}{square(-64.3, -31.5, -64.1, -31.3)}, g.Geometry.Coordinates)
+}
+
+func TestStoreGetRegions(t *testing.T) {
+	s := load(t)
+
+	var regions []model.Region
+
+	require.NoError(t, s.GetRegions(repository.QueryRegion{RegionType: model.RegionTypeCity, AncestorID: "178", Limit: 2}, &regions))
+	require.Len(t, regions, 2)
+	assert.Equal(t, "1", regions[0].GeoID)
+	assert.Equal(t, "2", regions[1].GeoID)
+
+	require.NoError(t, s.GetRegions(repository.QueryRegion{RegionType: model.RegionTypeCity, After: "2"}, &regions))
+	require.Len(t, regions, 1)
+	assert.Equal(t, "4", regions[0].GeoID)
+
+	require.NoError(t, s.GetRegions(repository.QueryRegion{
+		RegionType: model.RegionTypeCity, Name: "buenos aires", NameLanguages: []model.Language{"es"},
+	}, &regions))
+	require.Len(t, regions, 1)
+	assert.Equal(t, "2", regions[0].GeoID)
+
+	require.NoError(t, s.GetRegions(repository.QueryRegion{
+		RegionType: model.RegionTypeCity, Name: "end of the world", NameLanguages: []model.Language{"es"},
+	}, &regions))
+	require.Len(t, regions, 1)
+	assert.Equal(t, "4", regions[0].GeoID)
+
+	count, err := s.Count(repository.QueryRegion{RegionType: model.RegionTypeCity, CountryCode: "AR", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestStoreGetIntersectedRegions(t *testing.T) {
+	s := load(t)
+
+	var regions []model.GeoRegion
+
+	require.NoError(t, s.GetIntersectedRegions(*model.NewPointGeometry([]interface{}{-58.4, -34.6}), nil, &regions))
+	assert.ElementsMatch(t, []string{"178", "2"}, geoIDs(regions))
+
+	require.NoError(t, s.GetIntersectedRegions(*model.NewPointGeometry([]interface{}{-58.4, -34.6}),
+		[]model.RegionType{model.RegionTypeCity}, &regions))
+	assert.Equal(t, []string{"2"}, geoIDs(regions))
+
+	area := model.Geometry{Type: model.GeometryPolygon, Coordinates: square(-58.4, -34.61, -58.38, -34.6)}
+
+	require.NoError(t, s.GetIntersectedRegions(area, []model.RegionType{model.RegionTypeCity, model.RegionTypePOI}, &regions))
+	assert.ElementsMatch(t, []string{"2", "3"}, geoIDs(regions))
+}
+
+func TestStoreGetNearByRegions(t *testing.T) {
+	s := load(t)
+
+	regions, err := s.GetNearByRegions(repository.QueryNearby{
+		Latitude: -34.6037, Longitude: -58.3816, Radius: 1000,
+		RegionTypes: []model.RegionType{model.RegionTypeCity, model.RegionTypePOI},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, regions, 3)
+	assert.Equal(t, []string{"2", "3", "1"}, []string{regions[0].GeoID, regions[1].GeoID, regions[2].GeoID})
+	assert.Zero(t, regions[0].DistanceKm)
+	assert.InDelta(t, 640, regions[2].DistanceKm, 30)
+	assert.Empty(t, regions[0].Geometry.Type, "polygons are not returned")
+	assert.Equal(t, model.GeometryPoint, regions[1].Geometry.Type)
+
+	regions, err = s.GetNearByRegions(repository.QueryNearby{Latitude: -34.6037, Longitude: -58.3816, Radius: 1000, MinDistance: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "1", regions[0].GeoID)
+}
+
+func TestStoreAirports(t *testing.T) {
+	s := load(t)
+
+	var a model.AirportV2
+
+	require.NoError(t, s.GetAirportByIATACode("EZE", &a))
+	assert.Equal(t, "AR", a.CountryCode)
+	assert.True(t, errors.Is(s.GetAirportByIATACode("JFK", &a), pkgErrors.ErrEntityNotFound))
+
+	var airports []model.AirportV2
+
+	require.NoError(t, s.GetAirportByQuery(repository.QueryAirport{CountryCode: "AR"}, &airports))
+	require.Len(t, airports, 2)
+	assert.Equal(t, "AEP", airports[0].IataCode)
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("not a snapshot")))
+	assert.Error(t, err)
+}
+
+func geoIDs(regions []model.GeoRegion) []string {
+	ids := make([]string, 0, len(regions))
+
+	for _, r := range regions {
+		ids = append(ids, r.GeoID)
+	}
+
+	return ids
+}
@@ -0,0 +1,618 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/basset-la/api-geo/errors"
+	"github.com/basset-la/api-geo/model"
+	"github.com/basset-la/api-geo/repository"
+)
+
+// kmPerDegree is the length of a degree of latitude
+const kmPerDegree = 111.2
+
+// entry is a loaded region, bbox is only set when it has a geometry
+type entry struct {
+	region       model.Region
+	geometryType model.GeometryType
+	polygons     [][][][]float64
+	point        []float64
+	bbox         model.BoundingBox
+}
+
+// cell is a one degree square of the spatial index
+type cell struct {
+	lon, lat int
+}
+
+// Store answers the lookups of the MongoRepository from a snapshot in memory, it is safe for concurrent use.
+// Projections (the Fields of the queries) are not applied, the whole entities are returned.
+// The entities returned share their names, ancestors and geometries with the store and must not be modified.
+type Store struct {
+	CreatedAt time.Time
+
+	regions  map[model.RegionType][]*entry // sorted by geo id
+	byID     map[model.RegionType]map[string]*entry
+	geoIDs   map[string]*entry // the region of each geo id with a geometry, like the polygons table
+	grid     map[cell][]*entry
+	airports []*model.AirportV2 // sorted by iata code
+	byIATA   map[string]*model.AirportV2
+}
+
+// Open loads a snapshot file
+func Open(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot %s. %w", path, err)
+	}
+
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Load reads a snapshot and indexes it
+func Load(r io.Reader) (*Store, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot. %w", err)
+	}
+
+	defer gz.Close()
+
+	decoder := gob.NewDecoder(gz)
+
+	var h header
+
+	if err = decoder.Decode(&h); err != nil {
+		return nil, fmt.Errorf("invalid snapshot header. %w", err)
+	}
+
+	if h.Format != formatName || h.Version != formatVersion {
+		return nil, fmt.Errorf("unsupported snapshot %s version %d", h.Format, h.Version)
+	}
+
+	s := &Store{
+		CreatedAt: h.CreatedAt,
+		regions:   map[model.RegionType][]*entry{},
+		byID:      map[model.RegionType]map[string]*entry{},
+		geoIDs:    map[string]*entry{},
+		grid:      map[cell][]*entry{},
+		byIATA:    map[string]*model.AirportV2{},
+	}
+
+	for {
+		var rec record
+
+		err = decoder.Decode(&rec)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot record. %w", err)
+		}
+
+		if rec.Airport != nil {
+			s.airports = append(s.airports, rec.Airport)
+			s.byIATA[rec.Airport.IataCode] = rec.Airport
+		}
+
+		if rec.Region != nil {
+			s.add(rec.Region)
+		}
+	}
+
+	for _, entries := range s.regions {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].region.GeoID < entries[j].region.GeoID })
+	}
+
+	sort.Slice(s.airports, func(i, j int) bool { return s.airports[i].IataCode < s.airports[j].IataCode })
+
+	return s, nil
+}
+
+func (s *Store) add(r *region) {
+	e := &entry{region: r.Region, geometryType: r.GeometryType, polygons: r.Polygons, point: r.Point}
+	t := r.Region.Type
+
+	if s.byID[t] == nil {
+		s.byID[t] = map[string]*entry{}
+	}
+
+	s.regions[t] = append(s.regions[t], e)
+	s.byID[t][r.Region.GeoID] = e
+
+	switch {
+	case len(e.polygons) > 0:
+		e.bbox = boundsOf(e.polygons)
+	case len(e.point) >= 2:
+		e.bbox = model.BoundingBox{MinLongitude: e.point[0], MinLatitude: e.point[1], MaxLongitude: e.point[0], MaxLatitude: e.point[1]}
+	default:
+		return
+	}
+
+	s.geoIDs[r.Region.GeoID] = e
+
+	for _, c := range cellsOf(e.bbox) {
+		s.grid[c] = append(s.grid[c], e)
+	}
+}
+
+// boundsOf returns the bounding box of the outer rings of the polygons
+func boundsOf(polygons [][][][]float64) model.BoundingBox {
+	b := model.BoundingBox{MinLongitude: 180, MinLatitude: 90, MaxLongitude: -180, MaxLatitude: -90}
+
+	for _, polygon := range polygons {
+		for _, p := range polygon[0] {
+			b.MinLongitude, b.MaxLongitude = math.Min(b.MinLongitude, p[0]), math.Max(b.MaxLongitude, p[0])
+			b.MinLatitude, b.MaxLatitude = math.Min(b.MinLatitude, p[1]), math.Max(b.MaxLatitude, p[1])
+		}
+	}
+
+	return b
+}
+
+// cellsOf returns the cells of the index covered by a bounding box that does not cross the antimeridian
+func cellsOf(b model.BoundingBox) []cell {
+	minLon, maxLon := int(math.Floor(b.MinLongitude)), int(math.Floor(b.MaxLongitude))
+	minLat, maxLat := int(math.Floor(b.MinLatitude)), int(math.Floor(b.MaxLatitude))
+
+	cells := make([]cell, 0, (maxLon-minLon+1)*(maxLat-minLat+1))
+
+	for lon := minLon; lon <= maxLon; lon++ {
+		for lat := minLat; lat <= maxLat; lat++ {
+			cells = append(cells, cell{lon: lon, lat: lat})
+		}
+	}
+
+	return cells
+}
+
+// candidates returns the entries of the types whose cells overlap the box, without repetitions
+func (s *Store) candidates(b model.BoundingBox, regionTypes []model.RegionType) []*entry {
+	boxes := []model.BoundingBox{b}
+
+	if b.CrossesAntimeridian() {
+		boxes = []model.BoundingBox{
+			{MinLongitude: b.MinLongitude, MinLatitude: b.MinLatitude, MaxLongitude: 180, MaxLatitude: b.MaxLatitude},
+			{MinLongitude: -180, MinLatitude: b.MinLatitude, MaxLongitude: b.MaxLongitude, MaxLatitude: b.MaxLatitude},
+		}
+	}
+
+	seen := map[*entry]bool{}
+	result := make([]*entry, 0)
+
+	for _, box := range boxes {
+		for _, c := range cellsOf(box) {
+			for _, e := range s.grid[c] {
+				if seen[e] || !hasType(regionTypes, e.region.Type) {
+					continue
+				}
+
+				seen[e] = true
+				result = append(result, e)
+			}
+		}
+	}
+
+	return result
+}
+
+func hasType(regionTypes []model.RegionType, t model.RegionType) bool {
+	if len(regionTypes) == 0 {
+		return true
+	}
+
+	for _, rt := range regionTypes {
+		if rt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRegionByTypeAndGeoID returns a region by type and geo id
+func (s *Store) GetRegionByTypeAndGeoID(regionType model.RegionType, geoID string, r *model.Region) error {
+	e, ok := s.byID[regionType][geoID]
+	if !ok {
+		return fmt.Errorf("failed to get region by type %s and geo id %s. %w", regionType, geoID, pkgErrors.ErrEntityNotFound)
+	}
+
+	*r = e.region
+
+	return nil
+}
+
+// GetCountryByCountryCode returns a country for a given country code
+func (s *Store) GetCountryByCountryCode(countryCode string, r *model.Region) error {
+	if countryCode == "" {
+		return fmt.Errorf("invalid country code")
+	}
+
+	for _, e := range s.regions[model.RegionTypeCountry] {
+		if e.region.CountryCode == countryCode {
+			*r = e.region
+
+			return nil
+		}
+	}
+
+	return pkgErrors.ErrEntityNotFound
+}
+
+// GetRegions returns a slice of regions ordered by geo id
+func (s *Store) GetRegions(query repository.QueryRegion, r *[]model.Region) error {
+	regions := make([]model.Region, 0)
+	skip := 0
+
+	if query.Page > 0 && query.Limit > 0 {
+		skip = (query.Page - 1) * query.Limit
+	}
+
+	for _, e := range s.regions[query.RegionType] {
+		if query.After != "" && e.region.GeoID <= query.After || !matches(&e.region, query) {
+			continue
+		}
+
+		if skip > 0 {
+			skip--
+
+			continue
+		}
+
+		regions = append(regions, e.region)
+
+		if query.Limit > 0 && len(regions) == query.Limit {
+			break
+		}
+	}
+
+	*r = regions
+
+	return nil
+}
+
+// Count returns how many regions match the query, the pagination is ignored
+func (s *Store) Count(query repository.QueryRegion) (int, error) {
+	count := 0
+
+	for _, e := range s.regions[query.RegionType] {
+		if matches(&e.region, query) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// matches has the semantics of the mongo query of the repository
+func matches(r *model.Region, q repository.QueryRegion) bool {
+	if len(q.GeoIDs) > 0 && !contains(q.GeoIDs, r.GeoID) {
+		return false
+	}
+
+	for _, d := range q.Descendants {
+		if len(descendantsOf(r.Descendants, d)) == 0 {
+			return false
+		}
+	}
+
+	if q.CountryCode != "" && r.CountryCode != q.CountryCode {
+		return false
+	}
+
+	inAncestors := func(a model.Ancestor) bool {
+		return a.Type == q.AncestorsRegionType && contains(q.Ancestors, a.ID)
+	}
+
+	if len(q.Ancestors) > 0 && q.AncestorsRegionType != "" && q.AncestorsRegionType != q.RegionType && !hasAncestor(r, inAncestors) {
+		return false
+	}
+
+	isAncestor := func(a model.Ancestor) bool {
+		return a.ID == q.AncestorID && (q.AncestorType == "" || a.Type == q.AncestorType)
+	}
+
+	if q.AncestorID != "" && !hasAncestor(r, isAncestor) {
+		return false
+	}
+
+	if q.BoundingBox != nil && !q.BoundingBox.Contains(r.Center.Longitude, r.Center.Latitude) {
+		return false
+	}
+
+	if q.Name != "" && !hasName(r, q.Name, q.NameLanguages) {
+		return false
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAncestor(r *model.Region, match func(a model.Ancestor) bool) bool {
+	for _, a := range r.Ancestors {
+		if match(a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasName matches a name in any of the languages or an alias in every language, ignoring case
+func hasName(r *model.Region, name string, languages []model.Language) bool {
+	for _, l := range languages {
+		if strings.EqualFold(r.Name[l], name) {
+			return true
+		}
+	}
+
+	for _, aliases := range r.Aliases {
+		for _, alias := range aliases {
+			if strings.EqualFold(alias, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// descendantsOf returns the descendants by their stored name, e.g. city or point_of_interest
+func descendantsOf(d model.Descendants, name string) []string {
+	switch name {
+	case "city":
+		return d.Cities
+	case "country":
+		return d.Countries
+	case "point_of_interest":
+		return d.POIs
+	case "high_level_region":
+		return d.HighLevelRegions
+	case "train_station":
+		return d.TrainStations
+	case "metro_station":
+		return d.MetroStations
+	case "neighborhood":
+		return d.Neighbourhoods
+	case "multi_city_vicinity":
+		return d.MultiCityVicinities
+	case "province_state":
+		return d.ProvinceStates
+	case "accommodation":
+		return d.Accommodations
+	}
+
+	return nil
+}
+
+// GetGeoRegion returns the geometry of a region by geo id
+func (s *Store) GetGeoRegion(geoID string, r *model.GeoRegion) error {
+	e, ok := s.geoIDs[geoID]
+	if !ok {
+		return fmt.Errorf("geo region %s not found. %w", geoID, pkgErrors.ErrEntityNotFound)
+	}
+
+	*r = e.geoRegion()
+
+	return nil
+}
+
+func (e *entry) geoRegion() model.GeoRegion {
+	g := model.GeoRegion{BaseRegion: e.region.BaseRegion, Geometry: model.Geometry{Type: e.geometryType}}
+
+	switch e.geometryType {
+	case model.GeometryPoint:
+		g.Geometry.Point = e.point
+		g.Geometry.Coordinates = []interface{}{e.point[0], e.point[1]}
+	case model.GeometryPolygon:
+		g.Geometry.Polygon = e.polygons[0]
+		g.Geometry.Coordinates = coordinates(e.polygons[0])
+	case model.GeometryMultiPolygon:
+		g.Geometry.MultiPolygon = e.polygons
+		g.Geometry.Coordinates = coordinates(e.polygons)
+	}
+
+	return g
+}
+
+// coordinates converts nested float slices to the interface{} slices of the GeoJSON coordinates
+func coordinates(v interface{}) []interface{} {
+	switch c := v.(type) {
+	case [][][][]float64:
+		result := make([]interface{}, 0, len(c))
+		for _, p := range c {
+			result = append(result, coordinates(p))
+		}
+
+		return result
+	case [][][]float64:
+		result := make([]interface{}, 0, len(c))
+		for _, r := range c {
+			result = append(result, coordinates(r))
+		}
+
+		return result
+	case [][]float64:
+		result := make([]interface{}, 0, len(c))
+		for _, p := range c {
+			result = append(result, []interface{}{p[0], p[1]})
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// GetIntersectedRegions returns the id and type of the regions of the types whose geometry intersects the geometry,
+// of all types when there are none
+func (s *Store) GetIntersectedRegions(geometry model.Geometry, regionTypes []model.RegionType, r *[]model.GeoRegion) error {
+	var (
+		polygons [][][][]float64
+		point    []float64
+		bbox     model.BoundingBox
+	)
+
+	if geometry.Type == model.GeometryPoint {
+		var ok bool
+
+		if point, ok = pointOf(geometry); !ok {
+			return fmt.Errorf("invalid point")
+		}
+
+		bbox = model.BoundingBox{MinLongitude: point[0], MinLatitude: point[1], MaxLongitude: point[0], MaxLatitude: point[1]}
+	} else {
+		var err error
+
+		if polygons, err = geometry.Polygons(); err != nil {
+			return err
+		}
+
+		bbox = boundsOf(polygons)
+	}
+
+	regions := make([]model.GeoRegion, 0)
+
+	for _, e := range s.candidates(bbox, regionTypes) {
+		if e.intersects(polygons, point) {
+			regions = append(regions, model.GeoRegion{BaseRegion: e.region.BaseRegion})
+		}
+	}
+
+	*r = regions
+
+	return nil
+}
+
+func (e *entry) intersects(polygons [][][][]float64, point []float64) bool {
+	switch {
+	case point != nil && e.point != nil:
+		return point[0] == e.point[0] && point[1] == e.point[1]
+	case point != nil:
+		return model.PolygonsContain(e.polygons, point[0], point[1])
+	case e.point != nil:
+		return model.PolygonsContain(polygons, e.point[0], e.point[1])
+	}
+
+	return model.PolygonsIntersect(e.polygons, polygons)
+}
+
+// GetNearByRegions returns the regions in a radius sorted by distance, the distance to a polygon is 0 inside it.
+// Only the geometry of the points is returned.
+func (s *Store) GetNearByRegions(q repository.QueryNearby) ([]model.NearbyRegion, error) {
+	dLat := q.Radius / kmPerDegree
+	dLon := 180.0
+
+	if cos := math.Cos(q.Latitude * math.Pi / 180); cos > 0 && dLat/cos < 180 {
+		dLon = dLat / cos
+	}
+
+	bbox := model.BoundingBox{
+		MinLongitude: q.Longitude - dLon, MinLatitude: math.Max(q.Latitude-dLat, -90),
+		MaxLongitude: q.Longitude + dLon, MaxLatitude: math.Min(q.Latitude+dLat, 90),
+	}
+
+	switch {
+	case dLon >= 180:
+		bbox.MinLongitude, bbox.MaxLongitude = -180, 180
+	case bbox.MinLongitude < -180:
+		bbox.MinLongitude += 360
+	case bbox.MaxLongitude > 180:
+		bbox.MaxLongitude -= 360
+	}
+
+	regions := make([]model.NearbyRegion, 0)
+
+	for _, e := range s.candidates(bbox, q.RegionTypes) {
+		var distance float64
+
+		// as in the repository, the polygons are not sent
+		region := model.GeoRegion{BaseRegion: e.region.BaseRegion}
+
+		if e.point != nil {
+			distance = model.DistanceKm(model.Center{Longitude: q.Longitude, Latitude: q.Latitude}, model.Center{Longitude: e.point[0], Latitude: e.point[1]})
+			region = e.geoRegion()
+		} else {
+			distance = model.PolygonsDistanceKm(e.polygons, q.Longitude, q.Latitude)
+		}
+
+		if distance > q.Radius || distance < q.MinDistance {
+			continue
+		}
+
+		regions = append(regions, model.NearbyRegion{GeoRegion: region, DistanceKm: distance})
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		if regions[i].DistanceKm != regions[j].DistanceKm {
+			return regions[i].DistanceKm < regions[j].DistanceKm
+		}
+
+		return regions[i].GeoID < regions[j].GeoID
+	})
+
+	if q.Limit > 0 && len(regions) > q.Limit {
+		regions = regions[:q.Limit]
+	}
+
+	return regions, nil
+}
+
+// GetAirportByIATACode returns an airport by iataCode
+func (s *Store) GetAirportByIATACode(iataCode string, a *model.AirportV2) error {
+	airport, ok := s.byIATA[iataCode]
+	if !ok {
+		return pkgErrors.ErrEntityNotFound
+	}
+
+	*a = *airport
+
+	return nil
+}
+
+// GetAirportByQuery returns a slice of airports ordered by iata code
+func (s *Store) GetAirportByQuery(q repository.QueryAirport, a *[]model.AirportV2) error {
+	airports := make([]model.AirportV2, 0)
+
+	for _, airport := range s.airports {
+		if q.After != "" && airport.IataCode <= q.After ||
+			len(q.IataCodes) > 0 && !contains(q.IataCodes, airport.IataCode) ||
+			q.CountryCode != "" && airport.CountryCode != q.CountryCode ||
+			len(q.RegionIDs) > 0 && !contains(q.RegionIDs, airport.Region.ID) ||
+			q.RegionType != "" && airport.Region.Type != string(q.RegionType) {
+			continue
+		}
+
+		airports = append(airports, *airport)
+
+		if q.Limit > 0 && len(airports) == q.Limit {
+			break
+		}
+	}
+
+	*a = airports
+
+	return nil
+}